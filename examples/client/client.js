@@ -22,17 +22,23 @@ const SERVER_PORT = 28015;
 const SERVER_TIMEOUT = 10;
 const SERVER_PASSWORD = fs.readFileSync('/etc/rethinkdb/credentials/admin-password', 'utf8');
 
-r.connect({
+const options = {
     host: SERVER_HOST,
     port: SERVER_PORT,
     timeout: SERVER_TIMEOUT,
-    password: SERVER_PASSWORD,
-    ssl: {
+    password: SERVER_PASSWORD
+};
+
+// Connect with TLS when the client certificates are mounted, otherwise connect in plaintext.
+if (fs.existsSync('/etc/rethinkdb/tls/ca.crt')) {
+    options.ssl = {
         'ca': fs.readFileSync('/etc/rethinkdb/tls/ca.crt', 'utf8'),
         'cert': fs.readFileSync('/etc/rethinkdb/tls/client.crt', 'utf8'),
         'key': fs.readFileSync('/etc/rethinkdb/tls/client.key', 'utf8')
-    }
-}, function (err, conn) {
+    };
+}
+
+r.connect(options, function (err, conn) {
     if (err) throw err;
     r.db('rethinkdb').table('server_status').pluck('name').run(conn, function (err, res) {
         if (err) throw err;
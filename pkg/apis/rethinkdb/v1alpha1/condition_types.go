@@ -0,0 +1,81 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RethinkDBConditionType is the type of a condition.
+type RethinkDBConditionType string
+
+// RethinkDBCondition describes the state of a resource at a certain point.
+// +k8s:openapi-gen=true
+type RethinkDBCondition struct {
+	// Type is the type of the condition.
+	Type RethinkDBConditionType `json:"type"`
+
+	// Status of the condition, one of True, False or Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a unique, one-word, CamelCase reason for the last transition of the condition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable message with details about the last transition of the condition.
+	Message string `json:"message,omitempty"`
+}
+
+// FindCondition returns the condition of the given type from the given conditions, or nil if not found.
+func FindCondition(conditions []RethinkDBCondition, conditionType RethinkDBConditionType) *RethinkDBCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true if the condition of the given type is present and has a status of True.
+func IsConditionTrue(conditions []RethinkDBCondition, conditionType RethinkDBConditionType) bool {
+	condition := FindCondition(conditions, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// SetCondition adds or updates the condition of the given type in the given conditions.
+// The transition time is only changed when the status of the condition changes.
+func SetCondition(conditions *[]RethinkDBCondition, conditionType RethinkDBConditionType, status corev1.ConditionStatus, reason string, message string) {
+	existing := FindCondition(*conditions, conditionType)
+	if existing == nil {
+		*conditions = append(*conditions, RethinkDBCondition{
+			Type:               conditionType,
+			Status:             status,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reason,
+			Message:            message,
+		})
+		return
+	}
+
+	if existing.Status != status {
+		existing.Status = status
+		existing.LastTransitionTime = metav1.Now()
+	}
+	existing.Reason = reason
+	existing.Message = message
+}
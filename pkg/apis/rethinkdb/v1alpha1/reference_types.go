@@ -0,0 +1,62 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RethinkDBClusterReference references a RethinkDBCluster in the namespace of the referencing resource.
+// +k8s:openapi-gen=true
+type RethinkDBClusterReference struct {
+	// Name is the name of the RethinkDBCluster.
+	Name string `json:"name"`
+}
+
+// NamespacedName returns the namespaced name of the referenced RethinkDBCluster in the given namespace of the
+// referencing resource.
+func (ref RethinkDBClusterReference) NamespacedName(namespace string) types.NamespacedName {
+	return types.NamespacedName{Name: ref.Name, Namespace: namespace}
+}
+
+// ClusterReference returns the reference to the RethinkDBCluster of the RethinkDBBackup.
+func (b *RethinkDBBackup) ClusterReference() RethinkDBClusterReference {
+	return b.Spec.Cluster
+}
+
+// ClusterReference returns the reference to the RethinkDBCluster of the RethinkDBClientCert.
+func (cc *RethinkDBClientCert) ClusterReference() RethinkDBClusterReference {
+	return cc.Spec.Cluster
+}
+
+// ClusterReference returns the reference to the RethinkDBCluster of the RethinkDBDatabase.
+func (db *RethinkDBDatabase) ClusterReference() RethinkDBClusterReference {
+	return db.Spec.Cluster
+}
+
+// ClusterReference returns the reference to the RethinkDBCluster of the RethinkDBRestore.
+func (r *RethinkDBRestore) ClusterReference() RethinkDBClusterReference {
+	return r.Spec.Cluster
+}
+
+// ClusterReference returns the reference to the RethinkDBCluster of the RethinkDBTable.
+func (t *RethinkDBTable) ClusterReference() RethinkDBClusterReference {
+	return t.Spec.Cluster
+}
+
+// ClusterReference returns the reference to the RethinkDBCluster of the RethinkDBUser.
+func (u *RethinkDBUser) ClusterReference() RethinkDBClusterReference {
+	return u.Spec.Cluster
+}
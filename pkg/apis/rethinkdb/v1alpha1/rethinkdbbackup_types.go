@@ -0,0 +1,300 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RethinkDBS3Storage defines an S3-compatible bucket that backup archives are uploaded to.
+// +k8s:openapi-gen=true
+type RethinkDBS3Storage struct {
+	// Endpoint is the URL of the S3-compatible service, such as a MinIO server. Default: AWS S3
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region is the region of the bucket.
+	Region string `json:"region,omitempty"`
+
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the name of the archives in the bucket.
+	Prefix string `json:"prefix,omitempty"`
+
+	// CredentialsSecret is the name of the Secret with the accessKeyId and secretAccessKey keys used to access the
+	// bucket.
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// RethinkDBBackupEncryption defines the passphrase backup archives are encrypted with.
+// +k8s:openapi-gen=true
+type RethinkDBBackupEncryption struct {
+	// KeySecret is the name of the Secret with the passphrase. The archives are encrypted with AES-256-GCM, using a
+	// key derived from the passphrase.
+	KeySecret string `json:"keySecret"`
+
+	// Key is the key of the passphrase in the Secret. Default: key
+	Key string `json:"key,omitempty"`
+}
+
+// RethinkDBVolumeSnapshotStorage defines the CSI VolumeSnapshots taken of the data volume claims of the servers.
+// +k8s:openapi-gen=true
+type RethinkDBVolumeSnapshotStorage struct {
+	// VolumeSnapshotClassName is the name of the VolumeSnapshotClass of the snapshots. Default: the default class of
+	// the CSI driver
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// RethinkDBBackupStorage defines where backup archives are written. Exactly one of PersistentVolumeClaim, S3 or
+// VolumeSnapshot must be set.
+// +k8s:openapi-gen=true
+type RethinkDBBackupStorage struct {
+	// PersistentVolumeClaim is an existing claim the archives are written to.
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// S3 is an S3-compatible bucket the archives are uploaded to.
+	S3 *RethinkDBS3Storage `json:"s3,omitempty"`
+
+	// VolumeSnapshot takes a CSI VolumeSnapshot of the data volume claim of every server instead of running
+	// rethinkdb dump. Only clusters with a PersistentVolumeClaimSpec can be backed up with snapshots, and the
+	// snapshots can only be restored into a new cluster with the bootstrap of the cluster.
+	VolumeSnapshot *RethinkDBVolumeSnapshotStorage `json:"volumeSnapshot,omitempty"`
+
+	// Encryption encrypts the archives before they are written, and decrypts them when they are restored. The
+	// archives are not encrypted by default.
+	Encryption *RethinkDBBackupEncryption `json:"encryption,omitempty"`
+}
+
+// RethinkDBBackupRetention defines which archives or volume snapshots of a RethinkDBBackup are kept. A backup is kept
+// when any of the rules keeps it, all other backups are deleted after each successful backup.
+// +k8s:openapi-gen=true
+type RethinkDBBackupRetention struct {
+	// KeepLast is the number of most recent archives to keep.
+	KeepLast int32 `json:"keepLast,omitempty"`
+
+	// KeepDaily is the number of most recent days to keep the last archive of.
+	KeepDaily int32 `json:"keepDaily,omitempty"`
+
+	// KeepWeekly is the number of most recent weeks to keep the last archive of.
+	KeepWeekly int32 `json:"keepWeekly,omitempty"`
+
+	// KeepMonthly is the number of most recent months to keep the last archive of.
+	KeepMonthly int32 `json:"keepMonthly,omitempty"`
+}
+
+// RethinkDBBackupSpec defines the desired state of RethinkDBBackup
+// +k8s:openapi-gen=true
+type RethinkDBBackupSpec struct {
+	// Cluster references the RethinkDBCluster to back up. The cluster must be in the namespace of the backup, as the
+	// backup Job uses the admin and client Secrets of the cluster.
+	Cluster RethinkDBClusterReference `json:"cluster"`
+
+	// Schedule is a cron schedule to run the backup on, such as "0 2 * * *". A single backup is taken when no
+	// schedule is set.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Databases limits the backup to the given databases. Default: all databases
+	Databases []string `json:"databases,omitempty"`
+
+	// Tables limits the backup to the given tables, in database.table form. Default: all tables
+	Tables []string `json:"tables,omitempty"`
+
+	// Storage is where the backup archives are written.
+	Storage RethinkDBBackupStorage `json:"storage"`
+
+	// Retention deletes the archives or volume snapshots that are no longer needed after each successful backup. All
+	// backups are kept when no retention is set.
+	Retention *RethinkDBBackupRetention `json:"retention,omitempty"`
+
+	// Verify restores each successful backup into a throwaway single-node RethinkDBCluster and compares the number
+	// of documents in each restored table with the archive.
+	Verify bool `json:"verify,omitempty"`
+
+	// Image is the container image that runs rethinkdb dump. It must provide Python 3 with the packages of
+	// build/dump/requirements.txt. Default: jmckind/rethinkdb-operator-dump with the version of the operator
+	Image string `json:"image,omitempty"`
+}
+
+// RethinkDBBackupPhase is the phase of a single backup.
+type RethinkDBBackupPhase string
+
+const (
+	// BackupPhasePending means the backup Job has not started yet.
+	BackupPhasePending RethinkDBBackupPhase = "Pending"
+
+	// BackupPhaseRunning means the backup Job is running.
+	BackupPhaseRunning RethinkDBBackupPhase = "Running"
+
+	// BackupPhaseSucceeded means the archive has been written.
+	BackupPhaseSucceeded RethinkDBBackupPhase = "Succeeded"
+
+	// BackupPhaseFailed means the backup Job failed.
+	BackupPhaseFailed RethinkDBBackupPhase = "Failed"
+)
+
+// RethinkDBBackupVerificationPhase is the phase of the verification of a backup.
+type RethinkDBBackupVerificationPhase string
+
+const (
+	// VerificationPhaseRunning means the archive is being restored into the throwaway cluster.
+	VerificationPhaseRunning RethinkDBBackupVerificationPhase = "Running"
+
+	// VerificationPhaseVerified means every table of the archive was restored with the same number of documents.
+	VerificationPhaseVerified RethinkDBBackupVerificationPhase = "Verified"
+
+	// VerificationPhaseFailed means the archive could not be restored, or a restored table has a different number
+	// of documents.
+	VerificationPhaseFailed RethinkDBBackupVerificationPhase = "Failed"
+)
+
+// RethinkDBBackupVerification is the outcome of the verification of a single backup.
+// +k8s:openapi-gen=true
+type RethinkDBBackupVerification struct {
+	// Phase is the outcome of the verification.
+	Phase RethinkDBBackupVerificationPhase `json:"phase"`
+
+	// JobName is the name of the Job that restored the archive.
+	JobName string `json:"jobName,omitempty"`
+
+	// CompletionTime is the time the verification finished.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Tables are the verified tables, or the tables with a different number of documents when the verification
+	// failed.
+	Tables []string `json:"tables,omitempty"`
+
+	// Message is a human readable message with details about the verification.
+	Message string `json:"message,omitempty"`
+}
+
+// RethinkDBTableWriteHook is the write hook a table had before its writes were blocked.
+// +k8s:openapi-gen=true
+type RethinkDBTableWriteHook struct {
+	// Table is the table in database.table form.
+	Table string `json:"table"`
+
+	// Function is the serialized hook function.
+	Function []byte `json:"function"`
+}
+
+// RethinkDBBackupQuiesce is the state of the writes to the cluster while the volume snapshots of a backup are taken.
+// +k8s:openapi-gen=true
+type RethinkDBBackupQuiesce struct {
+	// Tables are the tables whose writes are blocked until every snapshot has been created, in database.table form.
+	Tables []string `json:"tables,omitempty"`
+
+	// WriteHooks are the write hooks of the tables before their writes were blocked, which are restored when the
+	// writes resume.
+	WriteHooks []RethinkDBTableWriteHook `json:"writeHooks,omitempty"`
+
+	// ResumeTime is the time the writes resumed.
+	ResumeTime *metav1.Time `json:"resumeTime,omitempty"`
+}
+
+// RethinkDBBackupRecord is the outcome of a single run of the backup.
+// +k8s:openapi-gen=true
+type RethinkDBBackupRecord struct {
+	// JobName is the name of the Job that took the backup.
+	JobName string `json:"jobName"`
+
+	// Phase is the outcome of the backup.
+	Phase RethinkDBBackupPhase `json:"phase"`
+
+	// StartTime is the time the backup Job started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the backup Job finished.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Duration is the time it took to dump the cluster and write the archive.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Archive is the location of the archive, a path on the claim or an s3:// URL.
+	Archive string `json:"archive,omitempty"`
+
+	// Size is the size of the archive in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// Tables are the tables included in the archive, in database.table form.
+	Tables []string `json:"tables,omitempty"`
+
+	// Snapshots are the VolumeSnapshots of the data volume claims of the servers, in server order, when the backup
+	// is taken with snapshots.
+	Snapshots []string `json:"snapshots,omitempty"`
+
+	// Quiesce is the state of the writes to the cluster while the snapshots are taken, when the backup is taken with
+	// snapshots.
+	Quiesce *RethinkDBBackupQuiesce `json:"quiesce,omitempty"`
+
+	// Pruned are the archives or volume snapshots deleted by the retention policy after the backup.
+	Pruned []string `json:"pruned,omitempty"`
+
+	// Message is a human readable message with details about a failed backup, or about archives that could not be
+	// pruned.
+	Message string `json:"message,omitempty"`
+
+	// Verification is the outcome of the verification of the archive, when the backup is verified.
+	Verification *RethinkDBBackupVerification `json:"verification,omitempty"`
+}
+
+// RethinkDBBackupStatus defines the observed state of RethinkDBBackup
+// +k8s:openapi-gen=true
+type RethinkDBBackupStatus struct {
+	// Phase is the phase of the most recent backup.
+	Phase RethinkDBBackupPhase `json:"phase,omitempty"`
+
+	// Message is a human readable message when the backup can not be run.
+	Message string `json:"message,omitempty"`
+
+	// LastScheduleTime is the last time a scheduled backup was started.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the last time a backup succeeded.
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// Backups are the most recent backups, newest first.
+	Backups []RethinkDBBackupRecord `json:"backups,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBBackup is the Schema for the rethinkdbbackups API
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name",description="The cluster that is backed up"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",description="The schedule of the backup"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the most recent backup"
+// +kubebuilder:printcolumn:name="Last Success",type="date",JSONPath=".status.lastSuccessfulTime",description="The last time a backup succeeded"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RethinkDBBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RethinkDBBackupSpec   `json:"spec,omitempty"`
+	Status RethinkDBBackupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBBackupList contains a list of RethinkDBBackup
+type RethinkDBBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RethinkDBBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RethinkDBBackup{}, &RethinkDBBackupList{})
+}
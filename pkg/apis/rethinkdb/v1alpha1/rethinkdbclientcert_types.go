@@ -0,0 +1,92 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RethinkDBClientCertSpec defines the desired state of RethinkDBClientCert
+// +k8s:openapi-gen=true
+type RethinkDBClientCertSpec struct {
+	// Cluster references the RethinkDBCluster whose CA issues the client certificate.
+	Cluster RethinkDBClusterReference `json:"cluster"`
+
+	// CommonName is the subject common name of the client certificate. Default: <namespace>.<name>
+	CommonName string `json:"commonName,omitempty"`
+
+	// SecretName is the name of the Secret the client certificate is stored in. Default: the name of the resource
+	SecretName string `json:"secretName,omitempty"`
+
+	// Duration is the lifetime of the client certificate. Default: the lifetime of the cluster certificates
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry the client certificate is renewed. Default: the renewal window of the
+	// cluster certificates
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+const (
+	// ClientCertConditionReady means the client certificate has been issued and is valid.
+	ClientCertConditionReady RethinkDBConditionType = "Ready"
+)
+
+// RethinkDBClientCertStatus defines the observed state of RethinkDBClientCert
+// +k8s:openapi-gen=true
+type RethinkDBClientCertStatus struct {
+	// Conditions are the latest available observations of the state of the client certificate.
+	Conditions []RethinkDBCondition `json:"conditions,omitempty"`
+
+	// SecretName is the name of the Secret holding the client certificate.
+	SecretName string `json:"secretName,omitempty"`
+
+	// SerialNumber is the serial number of the issued client certificate.
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// NotAfter is the time the client certificate expires.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is the time the client certificate will be renewed.
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBClientCert is the Schema for the rethinkdbclientcerts API
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name",description="The cluster the certificate is issued for"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.secretName",description="The Secret holding the certificate"
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.notAfter",description="The time the certificate expires"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RethinkDBClientCert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RethinkDBClientCertSpec   `json:"spec,omitempty"`
+	Status RethinkDBClientCertStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBClientCertList contains a list of RethinkDBClientCert
+type RethinkDBClientCertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RethinkDBClientCert `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RethinkDBClientCert{}, &RethinkDBClientCertList{})
+}
@@ -32,6 +32,131 @@ type RethinkDBPodPolicy struct {
 	PersistentVolumeClaimSpec *corev1.PersistentVolumeClaimSpec `json:"persistentVolumeClaimSpec,omitempty"`
 }
 
+// RethinkDBTLSPolicy defines the policy for the TLS certificates issued for the cluster.
+// +k8s:openapi-gen=true
+type RethinkDBTLSPolicy struct {
+	// Enabled turns TLS on or off for the cluster, driver and http ports. Default: true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Ports turns TLS on or off for individual ports, overriding Enabled.
+	Ports *RethinkDBTLSPorts `json:"ports,omitempty"`
+
+	// RequireClientCertificates requires clients of the driver port to present a certificate signed by the cluster CA,
+	// in addition to their password. Ignored when the driver port serves plaintext.
+	RequireClientCertificates bool `json:"requireClientCertificates,omitempty"`
+
+	// RenewBefore is how long before expiry the certificates are renewed. Default: 720h (30 days)
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// KeyAlgorithm is the algorithm of the private keys generated for the CA and certificates. Default: RSA
+	// +kubebuilder:validation:Enum=RSA,ECDSA
+	KeyAlgorithm RethinkDBKeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// KeySize is the size of the private keys in bits. RSA keys may be 2048, 3072 or 4096 bits, ECDSA keys 256 bits
+	// (P-256) or 384 bits (P-384). Default: 2048 for RSA, 256 for ECDSA
+	KeySize int32 `json:"keySize,omitempty"`
+
+	// CADuration is the lifetime of the generated CA certificate. Default: 8760h (365 days)
+	CADuration *metav1.Duration `json:"caDuration,omitempty"`
+
+	// Duration is the lifetime of the cluster, driver, http and client certificates. Default: 8760h (365 days)
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Organization is the subject organization of the CA and certificates. Default: the namespace of the cluster
+	Organization []string `json:"organization,omitempty"`
+
+	// CASecret is the name of an existing Secret with the CA certificate and private key used to sign the cluster
+	// certificates, instead of a generated self-signed CA. The optional ca.crt key holds the chain of the CA.
+	// When Secrets is set, only the CA certificate is required and it is used to verify the existing certificates.
+	CASecret string `json:"caSecret,omitempty"`
+
+	// Secrets are existing TLS Secrets to mount instead of issuing certificates for the cluster.
+	Secrets *RethinkDBTLSSecrets `json:"secrets,omitempty"`
+
+	// CertManager creates cert-manager Certificates for the cluster, instead of issuing certificates in the operator.
+	// Ignored when Secrets is set.
+	CertManager *RethinkDBCertManagerPolicy `json:"certManager,omitempty"`
+}
+
+// RethinkDBKeyAlgorithm is the algorithm of a private key.
+type RethinkDBKeyAlgorithm string
+
+const (
+	// KeyAlgorithmRSA means RSA private keys are generated.
+	KeyAlgorithmRSA RethinkDBKeyAlgorithm = "RSA"
+
+	// KeyAlgorithmECDSA means ECDSA private keys are generated.
+	KeyAlgorithmECDSA RethinkDBKeyAlgorithm = "ECDSA"
+)
+
+// RethinkDBCertManagerPolicy defines the cert-manager Certificates created for the cluster.
+// +k8s:openapi-gen=true
+type RethinkDBCertManagerPolicy struct {
+	// IssuerRef is the cert-manager Issuer or ClusterIssuer that issues the certificates.
+	IssuerRef RethinkDBIssuerReference `json:"issuerRef"`
+}
+
+// RethinkDBIssuerReference references a cert-manager Issuer or ClusterIssuer.
+// +k8s:openapi-gen=true
+type RethinkDBIssuerReference struct {
+	// Name is the name of the issuer.
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, either Issuer or ClusterIssuer. Default: Issuer
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer. Default: cert-manager.io
+	Group string `json:"group,omitempty"`
+}
+
+// RethinkDBTLSPorts turns TLS on or off for each port of the servers. Unset ports follow the Enabled field of the
+// TLS policy.
+// +k8s:openapi-gen=true
+type RethinkDBTLSPorts struct {
+	// Cluster turns TLS on or off for connections between servers.
+	Cluster *bool `json:"cluster,omitempty"`
+
+	// Driver turns TLS on or off for client driver connections.
+	Driver *bool `json:"driver,omitempty"`
+
+	// HTTP turns TLS on or off for the web-admin.
+	HTTP *bool `json:"http,omitempty"`
+}
+
+// RethinkDBTLSSecrets defines existing TLS Secrets to use for the cluster. The certificates are verified against the
+// CA, but are never renewed or re-issued by the operator.
+// +k8s:openapi-gen=true
+type RethinkDBTLSSecrets struct {
+	// Cluster is the name of the Secret with the certificate for connections between servers.
+	Cluster string `json:"cluster"`
+
+	// Driver is the name of the Secret with the certificate for client driver connections.
+	Driver string `json:"driver"`
+
+	// HTTP is the name of the Secret with the certificate for the web-admin.
+	HTTP string `json:"http"`
+
+	// Client is the name of the Secret with the client certificate the operator uses to connect to the cluster.
+	Client string `json:"client"`
+}
+
+// RethinkDBBootstrapPolicy defines the backup archive a new cluster is initialized from. Exactly one of Backup or
+// Source must be set.
+// +k8s:openapi-gen=true
+type RethinkDBBootstrapPolicy struct {
+	// Backup is the name of a RethinkDBBackup in the namespace of the cluster, the most recent successful archive of
+	// the backup is restored. When the backup is taken with volume snapshots, the data volume claims of the servers
+	// are provisioned from the most recent successful snapshots instead.
+	Backup string `json:"backup,omitempty"`
+
+	// Source is the archive to restore when it was not taken by a RethinkDBBackup in the namespace of the cluster.
+	Source *RethinkDBRestoreSource `json:"source,omitempty"`
+
+	// Image is the container image that runs rethinkdb restore. Default: jmckind/rethinkdb-operator-dump with the
+	// version of the operator
+	Image string `json:"image,omitempty"`
+}
+
 // RethinkDBClusterSpec defines the desired state of RethinkDBCluster
 // +k8s:openapi-gen=true
 type RethinkDBClusterSpec struct {
@@ -39,6 +164,7 @@ type RethinkDBClusterSpec struct {
 	Size int32 `json:"size"`
 
 	// Version is the RethinkDB version to use for the cluster.
+	// Changing the version of a running cluster upgrades the servers one at a time.
 	Version string `json:"version,omitempty"`
 
 	// WebAdminEnabled indicates whether or not the Web Admin will be enabled for the cluster.
@@ -47,22 +173,321 @@ type RethinkDBClusterSpec struct {
 	// Pod defines the policy for pods owned by rethinkdb operator.
 	// This field cannot be updated once the CR is created.
 	Pod *RethinkDBPodPolicy `json:"pod,omitempty"`
+
+	// TLS defines the policy for the TLS certificates issued for the cluster.
+	TLS *RethinkDBTLSPolicy `json:"tls,omitempty"`
+
+	// ClusterDomain is the DNS domain of the Kubernetes cluster, used for the server addresses and the names in the
+	// certificates. This field cannot be updated once the CR is created, changes are reverted to the domain in the
+	// status. Default: cluster.local
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Bootstrap initializes a new cluster from a backup archive. The archive is restored once all servers are ready
+	// and the cluster is only reported as available once the restore has completed.
+	// This field only applies when the cluster is created.
+	Bootstrap *RethinkDBBootstrapPolicy `json:"bootstrap,omitempty"`
+}
+
+// RethinkDBDrainPhase is the phase of a server drain.
+type RethinkDBDrainPhase string
+
+const (
+	// DrainPhaseReconfiguring means tables are being reconfigured away from the server.
+	DrainPhaseReconfiguring RethinkDBDrainPhase = "Reconfiguring"
+
+	// DrainPhaseWaiting means the operator is waiting for all table replicas to become ready.
+	DrainPhaseWaiting RethinkDBDrainPhase = "WaitingForReplicas"
+
+	// DrainPhaseRemoving means the server holds no table replicas and the server Pod is being removed.
+	DrainPhaseRemoving RethinkDBDrainPhase = "Removing"
+)
+
+// RethinkDBDrainStatus defines the progress of draining a server prior to removing it from the cluster.
+// +k8s:openapi-gen=true
+type RethinkDBDrainStatus struct {
+	// Server is the name of the server Pod being drained.
+	Server string `json:"server"`
+
+	// Phase is the current phase of the drain.
+	Phase RethinkDBDrainPhase `json:"phase"`
+
+	// StartTime is the time the drain was started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// RethinkDBUpgradeStatus defines the progress of a rolling upgrade of the servers to a new RethinkDB version.
+// +k8s:openapi-gen=true
+type RethinkDBUpgradeStatus struct {
+	// TargetVersion is the RethinkDB version the servers are being upgraded to.
+	TargetVersion string `json:"targetVersion"`
+
+	// StartTime is the time the upgrade was started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Server is the name of the server Pod currently being upgraded.
+	// This field is empty between servers.
+	Server string `json:"server,omitempty"`
+
+	// ServerStartTime is the time the upgrade of the current server was started.
+	ServerStartTime *metav1.Time `json:"serverStartTime,omitempty"`
+
+	// UpdatedServers is a list of the names of the server Pods that have been upgraded.
+	UpdatedServers []string `json:"updatedServers,omitempty"`
+
+	// Paused indicates the upgrade has stopped because the current server failed to rejoin the cluster.
+	// The upgrade continues once the server recovers, or when the version is changed.
+	Paused bool `json:"paused,omitempty"`
+
+	// Message explains why the upgrade is paused.
+	Message string `json:"message,omitempty"`
 }
 
+// RethinkDBCertificateStatus defines the state of a TLS certificate issued for the cluster.
+// +k8s:openapi-gen=true
+type RethinkDBCertificateStatus struct {
+	// Secret is the name of the Secret holding the certificate.
+	Secret string `json:"secret"`
+
+	// NotAfter is the time the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Message describes how an existing certificate differs from the certificate the operator would issue.
+	Message string `json:"message,omitempty"`
+}
+
+// RethinkDBTLSStatus defines the state of the TLS certificates issued for the cluster.
+// +k8s:openapi-gen=true
+type RethinkDBTLSStatus struct {
+	// CANotAfter is the time the cluster CA certificate expires.
+	CANotAfter *metav1.Time `json:"caNotAfter,omitempty"`
+
+	// Certificates is the state of each certificate used by the cluster.
+	Certificates []RethinkDBCertificateStatus `json:"certificates,omitempty"`
+
+	// Revision identifies the certificates the server Pods should be running with.
+	// Server Pods with a different revision are restarted one at a time.
+	Revision string `json:"revision,omitempty"`
+}
+
+// RethinkDBCARotationStage is the stage of a cluster CA rotation.
+type RethinkDBCARotationStage string
+
+const (
+	// CARotationTrustingNewCA means the new CA has been added to the trust bundle and the servers are restarting to
+	// trust both the old and new CA.
+	CARotationTrustingNewCA RethinkDBCARotationStage = "TrustingNewCA"
+
+	// CARotationReissuing means the new CA is signing certificates and the servers are restarting with certificates
+	// issued by the new CA.
+	CARotationReissuing RethinkDBCARotationStage = "ReissuingCertificates"
+
+	// CARotationRemovingOldCA means the old CA has been removed from the trust bundle and the servers are restarting
+	// to only trust the new CA.
+	CARotationRemovingOldCA RethinkDBCARotationStage = "RemovingOldCA"
+
+	// CARotationCompleted means the last CA rotation has completed.
+	CARotationCompleted RethinkDBCARotationStage = "Completed"
+)
+
+// RethinkDBCARotationStatus defines the progress of a rotation of the cluster CA.
+// +k8s:openapi-gen=true
+type RethinkDBCARotationStatus struct {
+	// Stage is the current stage of the rotation.
+	Stage RethinkDBCARotationStage `json:"stage"`
+
+	// Reason is why the rotation was started, either Requested or Expiring.
+	Reason string `json:"reason,omitempty"`
+
+	// Trigger is the value of the rotate-ca annotation when the rotation was started.
+	Trigger string `json:"trigger,omitempty"`
+
+	// Message describes what the current stage is waiting for, if anything.
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the rotation was started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the rotation completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// RethinkDBAdminPasswordRotationStage is the stage of an admin password rotation.
+type RethinkDBAdminPasswordRotationStage string
+
+const (
+	// AdminPasswordRotationApplying means a new password has been generated and is being applied to the admin user.
+	AdminPasswordRotationApplying RethinkDBAdminPasswordRotationStage = "Applying"
+
+	// AdminPasswordRotationCompleted means the last admin password rotation has completed.
+	AdminPasswordRotationCompleted RethinkDBAdminPasswordRotationStage = "Completed"
+)
+
+// RethinkDBAdminPasswordRotationStatus defines the progress of a rotation of the admin password.
+// +k8s:openapi-gen=true
+type RethinkDBAdminPasswordRotationStatus struct {
+	// Stage is the current stage of the rotation.
+	Stage RethinkDBAdminPasswordRotationStage `json:"stage"`
+
+	// Reason is why the rotation was started, either Requested or SecretUpdated.
+	Reason string `json:"reason,omitempty"`
+
+	// Trigger is the value of the rotate-admin-password annotation when the rotation was started.
+	Trigger string `json:"trigger,omitempty"`
+
+	// StartTime is the time the rotation was started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the rotation completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// RethinkDBBootstrapPhase is the phase of the initialization of a new cluster from a backup archive.
+type RethinkDBBootstrapPhase string
+
+const (
+	// BootstrapPhaseWaiting means the archive is restored once all servers are ready.
+	BootstrapPhaseWaiting RethinkDBBootstrapPhase = "Waiting"
+
+	// BootstrapPhaseRestoring means the archive is being restored.
+	BootstrapPhaseRestoring RethinkDBBootstrapPhase = "Restoring"
+
+	// BootstrapPhaseCompleted means the archive has been restored.
+	BootstrapPhaseCompleted RethinkDBBootstrapPhase = "Completed"
+
+	// BootstrapPhaseFailed means the restore Job failed, it is retried when the Job is deleted.
+	BootstrapPhaseFailed RethinkDBBootstrapPhase = "Failed"
+)
+
+// RethinkDBBootstrapStatus defines the progress of the initialization of a new cluster from a backup archive.
+// +k8s:openapi-gen=true
+type RethinkDBBootstrapStatus struct {
+	// Phase is the current phase of the bootstrap.
+	Phase RethinkDBBootstrapPhase `json:"phase"`
+
+	// Archive is the archive that is restored, or the backup Job that took the volume snapshots.
+	Archive string `json:"archive,omitempty"`
+
+	// Snapshots are the VolumeSnapshots the data volume claims of the servers are provisioned from, in server order,
+	// when the bootstrap backup was taken with snapshots.
+	Snapshots []string `json:"snapshots,omitempty"`
+
+	// JobName is the name of the Job that restores the archive.
+	JobName string `json:"jobName,omitempty"`
+
+	// StartTime is the time the restore Job started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the restore Job finished.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Tables are the restored tables, in database.table form.
+	Tables []string `json:"tables,omitempty"`
+
+	// Message is a human readable message with details about the bootstrap.
+	Message string `json:"message,omitempty"`
+}
+
+// RethinkDBClusterPhase is the overall phase of a RethinkDBCluster.
+type RethinkDBClusterPhase string
+
+const (
+	// ClusterPhaseCreating means the cluster is being created and is not yet available.
+	ClusterPhaseCreating RethinkDBClusterPhase = "Creating"
+
+	// ClusterPhaseRunning means all servers are ready and the cluster is healthy.
+	ClusterPhaseRunning RethinkDBClusterPhase = "Running"
+
+	// ClusterPhaseScaling means servers are being added to or removed from the cluster.
+	ClusterPhaseScaling RethinkDBClusterPhase = "Scaling"
+
+	// ClusterPhaseUpgrading means the servers are being upgraded to a new RethinkDB version.
+	ClusterPhaseUpgrading RethinkDBClusterPhase = "Upgrading"
+
+	// ClusterPhaseDegraded means the cluster is available, but not all servers are ready or issues were reported.
+	ClusterPhaseDegraded RethinkDBClusterPhase = "Degraded"
+
+	// ClusterPhaseFailed means the operator is unable to reconcile the cluster.
+	ClusterPhaseFailed RethinkDBClusterPhase = "Failed"
+)
+
+const (
+	// ClusterConditionAvailable means at least one server is ready to accept client connections.
+	ClusterConditionAvailable RethinkDBConditionType = "Available"
+
+	// ClusterConditionProgressing means the operator is making progress towards the desired state.
+	ClusterConditionProgressing RethinkDBConditionType = "Progressing"
+
+	// ClusterConditionDegraded means not all servers are ready or the cluster reported critical issues.
+	ClusterConditionDegraded RethinkDBConditionType = "Degraded"
+
+	// ClusterConditionTLSReady means all TLS assets for the cluster are present.
+	ClusterConditionTLSReady RethinkDBConditionType = "TLSReady"
+
+	// ClusterConditionScaling means servers are being added to or removed from the cluster.
+	ClusterConditionScaling RethinkDBConditionType = "ScalingInProgress"
+
+	// ClusterConditionUpgrading means the servers are being upgraded to a new RethinkDB version.
+	ClusterConditionUpgrading RethinkDBConditionType = "UpgradeInProgress"
+)
+
 // RethinkDBClusterStatus defines the observed state of RethinkDBCluster
 // +k8s:openapi-gen=true
 type RethinkDBClusterStatus struct {
+	// Phase is the overall phase of the cluster.
+	Phase RethinkDBClusterPhase `json:"phase,omitempty"`
+
+	// Conditions are the latest available observations of the state of the cluster.
+	Conditions []RethinkDBCondition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the cluster spec observed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ReadyServers is the number of server Pods that are ready.
+	ReadyServers int32 `json:"readyServers,omitempty"`
+
+	// Version is the RethinkDB version reported by the running servers.
+	Version string `json:"version,omitempty"`
+
 	// Servers is a list of the names of the rethinkdb server Pods in the cluster.
 	Servers []string `json:"servers,omitempty"`
 
 	// ServiceName is the name of the Service for accessing the RethinkDB cluster.
 	ServiceName string `json:"serviceName,omitempty"`
+
+	// ClusterDomain is the DNS domain of the Kubernetes cluster the servers were created with.
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Drain is the progress of the server currently being drained before scaling down the cluster.
+	// This field is empty when no server is being drained.
+	Drain *RethinkDBDrainStatus `json:"drain,omitempty"`
+
+	// Upgrade is the progress of the rolling upgrade to a new RethinkDB version.
+	// This field is empty when no upgrade is in progress.
+	Upgrade *RethinkDBUpgradeStatus `json:"upgrade,omitempty"`
+
+	// TLS is the state of the TLS certificates issued for the cluster.
+	TLS *RethinkDBTLSStatus `json:"tls,omitempty"`
+
+	// CARotation is the progress of the last rotation of the cluster CA.
+	CARotation *RethinkDBCARotationStatus `json:"caRotation,omitempty"`
+
+	// AdminPasswordRotation is the progress of the last rotation of the admin password.
+	AdminPasswordRotation *RethinkDBAdminPasswordRotationStatus `json:"adminPasswordRotation,omitempty"`
+
+	// Bootstrap is the progress of the initialization of the cluster from a backup archive.
+	// This field is empty when the cluster is not initialized from a backup.
+	Bootstrap *RethinkDBBootstrapStatus `json:"bootstrap,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // RethinkDBCluster is the Schema for the rethinkdbclusters API
 // +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the cluster"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".spec.size",description="The requested number of servers"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyServers",description="The number of ready servers"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="The running RethinkDB version"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type RethinkDBCluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
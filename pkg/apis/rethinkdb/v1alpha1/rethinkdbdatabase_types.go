@@ -0,0 +1,89 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RethinkDBRetentionPolicy determines what happens to data in the cluster when the resource that manages it is deleted.
+type RethinkDBRetentionPolicy string
+
+const (
+	// RetentionPolicyRetain leaves the data in the cluster when the resource is deleted.
+	RetentionPolicyRetain RethinkDBRetentionPolicy = "Retain"
+
+	// RetentionPolicyDelete drops the data from the cluster when the resource is deleted.
+	RetentionPolicyDelete RethinkDBRetentionPolicy = "Delete"
+)
+
+// RethinkDBDatabaseSpec defines the desired state of RethinkDBDatabase
+// +k8s:openapi-gen=true
+type RethinkDBDatabaseSpec struct {
+	// Cluster references the RethinkDBCluster the database is created in.
+	Cluster RethinkDBClusterReference `json:"cluster"`
+
+	// Name is the name of the database. This field cannot be updated once the CR is created.
+	// Default: the name of the resource
+	Name string `json:"name,omitempty"`
+
+	// RetentionPolicy determines whether the database and all of its tables are dropped when the resource is
+	// deleted, one of Retain or Delete. Default: Retain
+	RetentionPolicy RethinkDBRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+const (
+	// DatabaseConditionReady means the database exists in the cluster.
+	DatabaseConditionReady RethinkDBConditionType = "Ready"
+)
+
+// RethinkDBDatabaseStatus defines the observed state of RethinkDBDatabase
+// +k8s:openapi-gen=true
+type RethinkDBDatabaseStatus struct {
+	// Conditions are the latest available observations of the state of the database.
+	Conditions []RethinkDBCondition `json:"conditions,omitempty"`
+
+	// Name is the name of the database created in the cluster.
+	Name string `json:"name,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBDatabase is the Schema for the rethinkdbdatabases API
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name",description="The cluster the database is created in"
+// +kubebuilder:printcolumn:name="Database",type="string",JSONPath=".status.name",description="The name of the database"
+// +kubebuilder:printcolumn:name="Retention",type="string",JSONPath=".spec.retentionPolicy",description="What happens to the database when the resource is deleted"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RethinkDBDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RethinkDBDatabaseSpec   `json:"spec,omitempty"`
+	Status RethinkDBDatabaseStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBDatabaseList contains a list of RethinkDBDatabase
+type RethinkDBDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RethinkDBDatabase `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RethinkDBDatabase{}, &RethinkDBDatabaseList{})
+}
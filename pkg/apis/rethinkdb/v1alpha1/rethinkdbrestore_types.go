@@ -0,0 +1,121 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RethinkDBRestoreSource defines the backup archive that is restored.
+// +k8s:openapi-gen=true
+type RethinkDBRestoreSource struct {
+	// Archive is the name of the archive on the claim, or its key in the bucket. The archive location recorded in
+	// the status of a RethinkDBBackup can be used as is.
+	Archive string `json:"archive"`
+
+	// Storage is where the archive is read from.
+	Storage RethinkDBBackupStorage `json:"storage"`
+}
+
+// RethinkDBRestoreSpec defines the desired state of RethinkDBRestore
+// +k8s:openapi-gen=true
+type RethinkDBRestoreSpec struct {
+	// Cluster references the RethinkDBCluster to restore the archive into. The cluster must be in the namespace of
+	// the restore, as the restore Job uses the admin and client Secrets of the cluster.
+	Cluster RethinkDBClusterReference `json:"cluster"`
+
+	// Source is the backup archive to restore.
+	Source RethinkDBRestoreSource `json:"source"`
+
+	// Databases limits the restore to the given databases. Default: all databases in the archive
+	Databases []string `json:"databases,omitempty"`
+
+	// Tables limits the restore to the given tables, in database.table form. Default: all tables in the archive
+	Tables []string `json:"tables,omitempty"`
+
+	// Force allows restoring into tables that already exist, documents with the same primary key are overwritten.
+	// The restore refuses to run when any of the restored tables exist otherwise.
+	Force bool `json:"force,omitempty"`
+
+	// Shards overrides the number of shards of the restored tables. Default: the number of shards in the archive
+	Shards *int32 `json:"shards,omitempty"`
+
+	// Replicas overrides the number of replicas of the restored tables. Default: the number of replicas in the archive
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Image is the container image that runs rethinkdb restore. It must provide Python 3 with the packages of
+	// build/dump/requirements.txt. Default: jmckind/rethinkdb-operator-dump with the version of the operator
+	Image string `json:"image,omitempty"`
+}
+
+const (
+	// RestoreConditionProgressing means the restore Job is pending or running.
+	RestoreConditionProgressing RethinkDBConditionType = "Progressing"
+
+	// RestoreConditionComplete is the outcome of the restore, true once the archive has been restored and false
+	// when the restore failed.
+	RestoreConditionComplete RethinkDBConditionType = "Complete"
+)
+
+// RethinkDBRestoreStatus defines the observed state of RethinkDBRestore
+// +k8s:openapi-gen=true
+type RethinkDBRestoreStatus struct {
+	// Conditions are the latest available observations of the state of the restore.
+	Conditions []RethinkDBCondition `json:"conditions,omitempty"`
+
+	// JobName is the name of the Job that runs the restore.
+	JobName string `json:"jobName,omitempty"`
+
+	// StartTime is the time the restore Job started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the restore Job finished.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Duration is the time it took to restore the archive.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Tables are the restored tables, or the existing tables that stopped the restore, in database.table form.
+	Tables []string `json:"tables,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBRestore is the Schema for the rethinkdbrestores API
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name",description="The cluster the archive is restored into"
+// +kubebuilder:printcolumn:name="Archive",type="string",JSONPath=".spec.source.archive",description="The archive that is restored"
+// +kubebuilder:printcolumn:name="Complete",type="string",JSONPath=".status.conditions[?(@.type==\"Complete\")].status",description="Whether the archive has been restored"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RethinkDBRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RethinkDBRestoreSpec   `json:"spec,omitempty"`
+	Status RethinkDBRestoreStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBRestoreList contains a list of RethinkDBRestore
+type RethinkDBRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RethinkDBRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RethinkDBRestore{}, &RethinkDBRestoreList{})
+}
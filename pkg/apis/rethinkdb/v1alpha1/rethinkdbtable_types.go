@@ -0,0 +1,144 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RethinkDBIndex defines a secondary index of a table.
+// +k8s:openapi-gen=true
+type RethinkDBIndex struct {
+	// Name is the name of the index.
+	Name string `json:"name"`
+
+	// Fields are the fields the index is built on. An index on more than one field is a compound index.
+	// Default: the field with the name of the index
+	Fields []string `json:"fields,omitempty"`
+
+	// Multi indexes each element of an array field separately.
+	Multi bool `json:"multi,omitempty"`
+
+	// Geo indexes a geospatial field.
+	Geo bool `json:"geo,omitempty"`
+}
+
+// RethinkDBTableSpec defines the desired state of RethinkDBTable
+// +k8s:openapi-gen=true
+type RethinkDBTableSpec struct {
+	// Cluster references the RethinkDBCluster the table is created in.
+	Cluster RethinkDBClusterReference `json:"cluster"`
+
+	// Database is the name of the database the table is created in. The database must exist in the cluster.
+	Database string `json:"database"`
+
+	// Name is the name of the table. This field cannot be updated once the CR is created.
+	// Default: the name of the resource
+	Name string `json:"name,omitempty"`
+
+	// PrimaryKey is the name of the primary key field. This field cannot be updated once the CR is created.
+	// Default: id
+	PrimaryKey string `json:"primaryKey,omitempty"`
+
+	// Shards is the number of shards of the table. Default: 1
+	Shards int32 `json:"shards,omitempty"`
+
+	// Replicas is the number of replicas of each shard. Cannot be more than the number of servers in the cluster.
+	// Default: 1
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Durability is whether writes are acknowledged once they are written to disk or to memory, one of hard or
+	// soft. Default: hard
+	Durability string `json:"durability,omitempty"`
+
+	// WriteAcks is the number of replicas that must acknowledge a write, one of majority or single.
+	// Default: majority
+	WriteAcks string `json:"writeAcks,omitempty"`
+
+	// Indexes are the secondary indexes of the table. Indexes that are removed from the list are dropped.
+	Indexes []RethinkDBIndex `json:"indexes,omitempty"`
+
+	// RetentionPolicy determines whether the table and its data are dropped when the resource is deleted, one of
+	// Retain or Delete. Default: Retain
+	RetentionPolicy RethinkDBRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+const (
+	// TableConditionReady means the table matches the spec and all of its replicas are ready.
+	TableConditionReady RethinkDBConditionType = "Ready"
+)
+
+// RethinkDBTableStatus defines the observed state of RethinkDBTable
+// +k8s:openapi-gen=true
+type RethinkDBTableStatus struct {
+	// Conditions are the latest available observations of the state of the table.
+	Conditions []RethinkDBCondition `json:"conditions,omitempty"`
+
+	// ID is the UUID of the table in the cluster.
+	ID string `json:"id,omitempty"`
+
+	// Name is the name of the table created in the cluster.
+	Name string `json:"name,omitempty"`
+
+	// Shards is the observed number of shards of the table.
+	Shards int32 `json:"shards,omitempty"`
+
+	// Replicas is the observed number of replicas of each shard.
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Indexes are the names of the secondary indexes of the table.
+	Indexes []string `json:"indexes,omitempty"`
+
+	// AllReplicasReady is true when every replica of the table is ready, from the table_status system table.
+	AllReplicasReady bool `json:"allReplicasReady,omitempty"`
+
+	// ReadyForReads is true when the table can serve up-to-date reads, from the table_status system table.
+	ReadyForReads bool `json:"readyForReads,omitempty"`
+
+	// ReadyForWrites is true when the table can accept writes, from the table_status system table.
+	ReadyForWrites bool `json:"readyForWrites,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBTable is the Schema for the rethinkdbtables API
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name",description="The cluster the table is created in"
+// +kubebuilder:printcolumn:name="Database",type="string",JSONPath=".spec.database",description="The database of the table"
+// +kubebuilder:printcolumn:name="Table",type="string",JSONPath=".status.name",description="The name of the table"
+// +kubebuilder:printcolumn:name="Shards",type="integer",JSONPath=".status.shards",description="The number of shards"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="The number of replicas of each shard"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.allReplicasReady",description="Whether all replicas are ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RethinkDBTable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RethinkDBTableSpec   `json:"spec,omitempty"`
+	Status RethinkDBTableStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBTableList contains a list of RethinkDBTable
+type RethinkDBTableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RethinkDBTable `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RethinkDBTable{}, &RethinkDBTableList{})
+}
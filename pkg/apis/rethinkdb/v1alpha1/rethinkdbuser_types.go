@@ -0,0 +1,108 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RethinkDBPermission grants permissions to a user globally, on a database or on a table. Permissions that are not
+// set are inherited from the enclosing scope.
+// +k8s:openapi-gen=true
+type RethinkDBPermission struct {
+	// Database is the database the permissions apply to. Permissions without a database apply globally.
+	Database string `json:"database,omitempty"`
+
+	// Table is the table in the database the permissions apply to. Permissions without a table apply to the whole
+	// database.
+	Table string `json:"table,omitempty"`
+
+	// Read allows the user to read data.
+	Read *bool `json:"read,omitempty"`
+
+	// Write allows the user to modify data.
+	Write *bool `json:"write,omitempty"`
+
+	// Connect allows the user to open HTTP connections with r.http. Only valid for global permissions.
+	Connect *bool `json:"connect,omitempty"`
+
+	// Config allows the user to create, reconfigure and drop databases and tables.
+	Config *bool `json:"config,omitempty"`
+}
+
+// RethinkDBUserSpec defines the desired state of RethinkDBUser
+// +k8s:openapi-gen=true
+type RethinkDBUserSpec struct {
+	// Cluster references the RethinkDBCluster the user is created in.
+	Cluster RethinkDBClusterReference `json:"cluster"`
+
+	// Username is the name of the user account. This field cannot be updated once the CR is created.
+	// Default: the name of the resource
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecret is the name of the Secret with the password of the user in the password key. The Secret is
+	// created with a random password when it does not exist. Default: <name>-password
+	PasswordSecret string `json:"passwordSecret,omitempty"`
+
+	// Permissions are the global, per-database and per-table permissions of the user. Permissions that are removed
+	// from the list are revoked.
+	Permissions []RethinkDBPermission `json:"permissions,omitempty"`
+}
+
+const (
+	// UserConditionReady means the user account and its permissions match the spec.
+	UserConditionReady RethinkDBConditionType = "Ready"
+)
+
+// RethinkDBUserStatus defines the observed state of RethinkDBUser
+// +k8s:openapi-gen=true
+type RethinkDBUserStatus struct {
+	// Conditions are the latest available observations of the state of the user.
+	Conditions []RethinkDBCondition `json:"conditions,omitempty"`
+
+	// Username is the name of the user account created in the cluster.
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretVersion is the resource version of the password Secret last applied to the user account.
+	PasswordSecretVersion string `json:"passwordSecretVersion,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBUser is the Schema for the rethinkdbusers API
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name",description="The cluster the user is created in"
+// +kubebuilder:printcolumn:name="Username",type="string",JSONPath=".status.username",description="The name of the user account"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RethinkDBUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RethinkDBUserSpec   `json:"spec,omitempty"`
+	Status RethinkDBUserStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RethinkDBUserList contains a list of RethinkDBUser
+type RethinkDBUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RethinkDBUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RethinkDBUser{}, &RethinkDBUserList{})
+}
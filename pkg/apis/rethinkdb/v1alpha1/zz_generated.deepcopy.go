@@ -21,12 +21,37 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RethinkDBCluster) DeepCopyInto(out *RethinkDBCluster) {
+func (in *RethinkDBAdminPasswordRotationStatus) DeepCopyInto(out *RethinkDBAdminPasswordRotationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBAdminPasswordRotationStatus.
+func (in *RethinkDBAdminPasswordRotationStatus) DeepCopy() *RethinkDBAdminPasswordRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBAdminPasswordRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBackup) DeepCopyInto(out *RethinkDBBackup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -35,18 +60,18 @@ func (in *RethinkDBCluster) DeepCopyInto(out *RethinkDBCluster) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBCluster.
-func (in *RethinkDBCluster) DeepCopy() *RethinkDBCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackup.
+func (in *RethinkDBBackup) DeepCopy() *RethinkDBBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(RethinkDBCluster)
+	out := new(RethinkDBBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RethinkDBCluster) DeepCopyObject() runtime.Object {
+func (in *RethinkDBBackup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -54,13 +79,29 @@ func (in *RethinkDBCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RethinkDBClusterList) DeepCopyInto(out *RethinkDBClusterList) {
+func (in *RethinkDBBackupEncryption) DeepCopyInto(out *RethinkDBBackupEncryption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupEncryption.
+func (in *RethinkDBBackupEncryption) DeepCopy() *RethinkDBBackupEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBBackupEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBackupList) DeepCopyInto(out *RethinkDBBackupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RethinkDBCluster, len(*in))
+		*out = make([]RethinkDBBackup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -68,18 +109,18 @@ func (in *RethinkDBClusterList) DeepCopyInto(out *RethinkDBClusterList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClusterList.
-func (in *RethinkDBClusterList) DeepCopy() *RethinkDBClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupList.
+func (in *RethinkDBBackupList) DeepCopy() *RethinkDBBackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(RethinkDBClusterList)
+	out := new(RethinkDBBackupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RethinkDBClusterList) DeepCopyObject() runtime.Object {
+func (in *RethinkDBBackupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -87,65 +128,1449 @@ func (in *RethinkDBClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RethinkDBClusterSpec) DeepCopyInto(out *RethinkDBClusterSpec) {
+func (in *RethinkDBBackupQuiesce) DeepCopyInto(out *RethinkDBBackupQuiesce) {
 	*out = *in
-	if in.Pod != nil {
-		in, out := &in.Pod, &out.Pod
-		*out = new(RethinkDBPodPolicy)
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WriteHooks != nil {
+		in, out := &in.WriteHooks, &out.WriteHooks
+		*out = make([]RethinkDBTableWriteHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResumeTime != nil {
+		in, out := &in.ResumeTime, &out.ResumeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupQuiesce.
+func (in *RethinkDBBackupQuiesce) DeepCopy() *RethinkDBBackupQuiesce {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBBackupQuiesce)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBackupRecord) DeepCopyInto(out *RethinkDBBackupRecord) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quiesce != nil {
+		in, out := &in.Quiesce, &out.Quiesce
+		*out = new(RethinkDBBackupQuiesce)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pruned != nil {
+		in, out := &in.Pruned, &out.Pruned
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RethinkDBBackupVerification)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClusterSpec.
-func (in *RethinkDBClusterSpec) DeepCopy() *RethinkDBClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupRecord.
+func (in *RethinkDBBackupRecord) DeepCopy() *RethinkDBBackupRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(RethinkDBClusterSpec)
+	out := new(RethinkDBBackupRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RethinkDBClusterStatus) DeepCopyInto(out *RethinkDBClusterStatus) {
+func (in *RethinkDBBackupRetention) DeepCopyInto(out *RethinkDBBackupRetention) {
 	*out = *in
-	if in.Servers != nil {
-		in, out := &in.Servers, &out.Servers
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupRetention.
+func (in *RethinkDBBackupRetention) DeepCopy() *RethinkDBBackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBBackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBackupSpec) DeepCopyInto(out *RethinkDBBackupSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RethinkDBBackupRetention)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClusterStatus.
-func (in *RethinkDBClusterStatus) DeepCopy() *RethinkDBClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupSpec.
+func (in *RethinkDBBackupSpec) DeepCopy() *RethinkDBBackupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RethinkDBClusterStatus)
+	out := new(RethinkDBBackupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RethinkDBPodPolicy) DeepCopyInto(out *RethinkDBPodPolicy) {
+func (in *RethinkDBBackupStatus) DeepCopyInto(out *RethinkDBBackupStatus) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.PersistentVolumeClaimSpec != nil {
-		in, out := &in.PersistentVolumeClaimSpec, &out.PersistentVolumeClaimSpec
-		*out = new(v1.PersistentVolumeClaimSpec)
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]RethinkDBBackupRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupStatus.
+func (in *RethinkDBBackupStatus) DeepCopy() *RethinkDBBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBackupStorage) DeepCopyInto(out *RethinkDBBackupStorage) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(RethinkDBS3Storage)
+		**out = **in
+	}
+	if in.VolumeSnapshot != nil {
+		in, out := &in.VolumeSnapshot, &out.VolumeSnapshot
+		*out = new(RethinkDBVolumeSnapshotStorage)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(RethinkDBBackupEncryption)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupStorage.
+func (in *RethinkDBBackupStorage) DeepCopy() *RethinkDBBackupStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBBackupStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBackupVerification) DeepCopyInto(out *RethinkDBBackupVerification) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBackupVerification.
+func (in *RethinkDBBackupVerification) DeepCopy() *RethinkDBBackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBBackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBootstrapPolicy) DeepCopyInto(out *RethinkDBBootstrapPolicy) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(RethinkDBRestoreSource)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBPodPolicy.
-func (in *RethinkDBPodPolicy) DeepCopy() *RethinkDBPodPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBootstrapPolicy.
+func (in *RethinkDBBootstrapPolicy) DeepCopy() *RethinkDBBootstrapPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RethinkDBPodPolicy)
+	out := new(RethinkDBBootstrapPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBBootstrapStatus) DeepCopyInto(out *RethinkDBBootstrapStatus) {
+	*out = *in
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBBootstrapStatus.
+func (in *RethinkDBBootstrapStatus) DeepCopy() *RethinkDBBootstrapStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBBootstrapStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBCARotationStatus) DeepCopyInto(out *RethinkDBCARotationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBCARotationStatus.
+func (in *RethinkDBCARotationStatus) DeepCopy() *RethinkDBCARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBCARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBCertManagerPolicy) DeepCopyInto(out *RethinkDBCertManagerPolicy) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBCertManagerPolicy.
+func (in *RethinkDBCertManagerPolicy) DeepCopy() *RethinkDBCertManagerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBCertManagerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBCertificateStatus) DeepCopyInto(out *RethinkDBCertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBCertificateStatus.
+func (in *RethinkDBCertificateStatus) DeepCopy() *RethinkDBCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClientCert) DeepCopyInto(out *RethinkDBClientCert) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClientCert.
+func (in *RethinkDBClientCert) DeepCopy() *RethinkDBClientCert {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClientCert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBClientCert) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClientCertList) DeepCopyInto(out *RethinkDBClientCertList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RethinkDBClientCert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClientCertList.
+func (in *RethinkDBClientCertList) DeepCopy() *RethinkDBClientCertList {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClientCertList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBClientCertList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClientCertSpec) DeepCopyInto(out *RethinkDBClientCertSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClientCertSpec.
+func (in *RethinkDBClientCertSpec) DeepCopy() *RethinkDBClientCertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClientCertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClientCertStatus) DeepCopyInto(out *RethinkDBClientCertStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RethinkDBCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClientCertStatus.
+func (in *RethinkDBClientCertStatus) DeepCopy() *RethinkDBClientCertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClientCertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBCluster) DeepCopyInto(out *RethinkDBCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBCluster.
+func (in *RethinkDBCluster) DeepCopy() *RethinkDBCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClusterList) DeepCopyInto(out *RethinkDBClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RethinkDBCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClusterList.
+func (in *RethinkDBClusterList) DeepCopy() *RethinkDBClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClusterReference) DeepCopyInto(out *RethinkDBClusterReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClusterReference.
+func (in *RethinkDBClusterReference) DeepCopy() *RethinkDBClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClusterSpec) DeepCopyInto(out *RethinkDBClusterSpec) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(RethinkDBPodPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RethinkDBTLSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(RethinkDBBootstrapPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClusterSpec.
+func (in *RethinkDBClusterSpec) DeepCopy() *RethinkDBClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBClusterStatus) DeepCopyInto(out *RethinkDBClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RethinkDBCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(RethinkDBDrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(RethinkDBUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RethinkDBTLSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(RethinkDBCARotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminPasswordRotation != nil {
+		in, out := &in.AdminPasswordRotation, &out.AdminPasswordRotation
+		*out = new(RethinkDBAdminPasswordRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(RethinkDBBootstrapStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBClusterStatus.
+func (in *RethinkDBClusterStatus) DeepCopy() *RethinkDBClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBCondition) DeepCopyInto(out *RethinkDBCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBCondition.
+func (in *RethinkDBCondition) DeepCopy() *RethinkDBCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBDatabase) DeepCopyInto(out *RethinkDBDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBDatabase.
+func (in *RethinkDBDatabase) DeepCopy() *RethinkDBDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBDatabaseList) DeepCopyInto(out *RethinkDBDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RethinkDBDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBDatabaseList.
+func (in *RethinkDBDatabaseList) DeepCopy() *RethinkDBDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBDatabaseSpec) DeepCopyInto(out *RethinkDBDatabaseSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBDatabaseSpec.
+func (in *RethinkDBDatabaseSpec) DeepCopy() *RethinkDBDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBDatabaseStatus) DeepCopyInto(out *RethinkDBDatabaseStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RethinkDBCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBDatabaseStatus.
+func (in *RethinkDBDatabaseStatus) DeepCopy() *RethinkDBDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBDrainStatus) DeepCopyInto(out *RethinkDBDrainStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBDrainStatus.
+func (in *RethinkDBDrainStatus) DeepCopy() *RethinkDBDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBIndex) DeepCopyInto(out *RethinkDBIndex) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBIndex.
+func (in *RethinkDBIndex) DeepCopy() *RethinkDBIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBIssuerReference) DeepCopyInto(out *RethinkDBIssuerReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBIssuerReference.
+func (in *RethinkDBIssuerReference) DeepCopy() *RethinkDBIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBPermission) DeepCopyInto(out *RethinkDBPermission) {
+	*out = *in
+	if in.Read != nil {
+		in, out := &in.Read, &out.Read
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Write != nil {
+		in, out := &in.Write, &out.Write
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Connect != nil {
+		in, out := &in.Connect, &out.Connect
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBPermission.
+func (in *RethinkDBPermission) DeepCopy() *RethinkDBPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBPodPolicy) DeepCopyInto(out *RethinkDBPodPolicy) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.PersistentVolumeClaimSpec != nil {
+		in, out := &in.PersistentVolumeClaimSpec, &out.PersistentVolumeClaimSpec
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBPodPolicy.
+func (in *RethinkDBPodPolicy) DeepCopy() *RethinkDBPodPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBPodPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBRestore) DeepCopyInto(out *RethinkDBRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBRestore.
+func (in *RethinkDBRestore) DeepCopy() *RethinkDBRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBRestoreList) DeepCopyInto(out *RethinkDBRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RethinkDBRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBRestoreList.
+func (in *RethinkDBRestoreList) DeepCopy() *RethinkDBRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBRestoreSource) DeepCopyInto(out *RethinkDBRestoreSource) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBRestoreSource.
+func (in *RethinkDBRestoreSource) DeepCopy() *RethinkDBRestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBRestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBRestoreSpec) DeepCopyInto(out *RethinkDBRestoreSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBRestoreSpec.
+func (in *RethinkDBRestoreSpec) DeepCopy() *RethinkDBRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBRestoreStatus) DeepCopyInto(out *RethinkDBRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RethinkDBCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBRestoreStatus.
+func (in *RethinkDBRestoreStatus) DeepCopy() *RethinkDBRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBS3Storage) DeepCopyInto(out *RethinkDBS3Storage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBS3Storage.
+func (in *RethinkDBS3Storage) DeepCopy() *RethinkDBS3Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBS3Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTLSPolicy) DeepCopyInto(out *RethinkDBTLSPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(RethinkDBTLSPorts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CADuration != nil {
+		in, out := &in.CADuration, &out.CADuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Organization != nil {
+		in, out := &in.Organization, &out.Organization
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = new(RethinkDBTLSSecrets)
+		**out = **in
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(RethinkDBCertManagerPolicy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTLSPolicy.
+func (in *RethinkDBTLSPolicy) DeepCopy() *RethinkDBTLSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTLSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTLSPorts) DeepCopyInto(out *RethinkDBTLSPorts) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Driver != nil {
+		in, out := &in.Driver, &out.Driver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTLSPorts.
+func (in *RethinkDBTLSPorts) DeepCopy() *RethinkDBTLSPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTLSPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTLSSecrets) DeepCopyInto(out *RethinkDBTLSSecrets) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTLSSecrets.
+func (in *RethinkDBTLSSecrets) DeepCopy() *RethinkDBTLSSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTLSSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTLSStatus) DeepCopyInto(out *RethinkDBTLSStatus) {
+	*out = *in
+	if in.CANotAfter != nil {
+		in, out := &in.CANotAfter, &out.CANotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]RethinkDBCertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTLSStatus.
+func (in *RethinkDBTLSStatus) DeepCopy() *RethinkDBTLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTable) DeepCopyInto(out *RethinkDBTable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTable.
+func (in *RethinkDBTable) DeepCopy() *RethinkDBTable {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBTable) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTableList) DeepCopyInto(out *RethinkDBTableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RethinkDBTable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTableList.
+func (in *RethinkDBTableList) DeepCopy() *RethinkDBTableList {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBTableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTableSpec) DeepCopyInto(out *RethinkDBTableSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Indexes != nil {
+		in, out := &in.Indexes, &out.Indexes
+		*out = make([]RethinkDBIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTableSpec.
+func (in *RethinkDBTableSpec) DeepCopy() *RethinkDBTableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTableStatus) DeepCopyInto(out *RethinkDBTableStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RethinkDBCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Indexes != nil {
+		in, out := &in.Indexes, &out.Indexes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTableStatus.
+func (in *RethinkDBTableStatus) DeepCopy() *RethinkDBTableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBTableWriteHook) DeepCopyInto(out *RethinkDBTableWriteHook) {
+	*out = *in
+	if in.Function != nil {
+		in, out := &in.Function, &out.Function
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBTableWriteHook.
+func (in *RethinkDBTableWriteHook) DeepCopy() *RethinkDBTableWriteHook {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBTableWriteHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBUpgradeStatus) DeepCopyInto(out *RethinkDBUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ServerStartTime != nil {
+		in, out := &in.ServerStartTime, &out.ServerStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UpdatedServers != nil {
+		in, out := &in.UpdatedServers, &out.UpdatedServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBUpgradeStatus.
+func (in *RethinkDBUpgradeStatus) DeepCopy() *RethinkDBUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBUser) DeepCopyInto(out *RethinkDBUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBUser.
+func (in *RethinkDBUser) DeepCopy() *RethinkDBUser {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBUserList) DeepCopyInto(out *RethinkDBUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RethinkDBUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBUserList.
+func (in *RethinkDBUserList) DeepCopy() *RethinkDBUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RethinkDBUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBUserSpec) DeepCopyInto(out *RethinkDBUserSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]RethinkDBPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBUserSpec.
+func (in *RethinkDBUserSpec) DeepCopy() *RethinkDBUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBUserStatus) DeepCopyInto(out *RethinkDBUserStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RethinkDBCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBUserStatus.
+func (in *RethinkDBUserStatus) DeepCopy() *RethinkDBUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RethinkDBVolumeSnapshotStorage) DeepCopyInto(out *RethinkDBVolumeSnapshotStorage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RethinkDBVolumeSnapshotStorage.
+func (in *RethinkDBVolumeSnapshotStorage) DeepCopy() *RethinkDBVolumeSnapshotStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(RethinkDBVolumeSnapshotStorage)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -13,18 +13,111 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCluster":       schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCluster(ref),
-		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterSpec":   schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterSpec(ref),
-		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterStatus": schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterStatus(ref),
-		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPodPolicy":     schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBPodPolicy(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBAdminPasswordRotationStatus": schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBAdminPasswordRotationStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackup":                      schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackup(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupEncryption":            schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupEncryption(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupQuiesce":               schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupQuiesce(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupRecord":                schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupRecord(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupRetention":             schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupRetention(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupSpec":                  schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupSpec(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStatus":                schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStorage":               schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupStorage(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupVerification":          schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupVerification(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBootstrapPolicy":             schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBootstrapPolicy(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBootstrapStatus":             schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBootstrapStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCARotationStatus":            schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCARotationStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCertManagerPolicy":           schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCertManagerPolicy(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCertificateStatus":           schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCertificateStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClientCert":                  schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClientCert(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClientCertSpec":              schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClientCertSpec(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClientCertStatus":            schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClientCertStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCluster":                     schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCluster(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference":            schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterReference(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterSpec":                 schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterSpec(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterStatus":               schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition":                   schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCondition(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDatabase":                    schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDatabase(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDatabaseSpec":                schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDatabaseSpec(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDatabaseStatus":              schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDatabaseStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDrainStatus":                 schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDrainStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBIndex":                       schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBIndex(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBIssuerReference":             schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBIssuerReference(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPermission":                  schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBPermission(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPodPolicy":                   schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBPodPolicy(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestore":                     schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestore(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSource":               schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestoreSource(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSpec":                 schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestoreSpec(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreStatus":               schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestoreStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBS3Storage":                   schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBS3Storage(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSPolicy":                   schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSPolicy(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSPorts":                    schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSPorts(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSSecrets":                  schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSSecrets(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSStatus":                   schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTable":                       schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTable(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableSpec":                   schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTableSpec(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableStatus":                 schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTableStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableWriteHook":              schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTableWriteHook(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUpgradeStatus":               schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUpgradeStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUser":                        schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUser(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUserSpec":                    schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUserSpec(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUserStatus":                  schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUserStatus(ref),
+		"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBVolumeSnapshotStorage":       schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBVolumeSnapshotStorage(ref),
 	}
 }
 
-func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBAdminPasswordRotationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RethinkDBCluster is the Schema for the rethinkdbclusters API",
+				Description: "RethinkDBAdminPasswordRotationStatus defines the progress of a rotation of the admin password.",
+				Properties: map[string]spec.Schema{
+					"stage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stage is the current stage of the rotation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is why the rotation was started, either Requested or SecretUpdated.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"trigger": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Trigger is the value of the rotate-admin-password annotation when the rotation was started.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the rotation was started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the rotation completed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"stage"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBackup is the Schema for the rethinkdbbackups API",
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -47,73 +140,152 @@ func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCluster(ref common.ReferenceCal
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterSpec"),
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterStatus"),
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupEncryption(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RethinkDBClusterSpec defines the desired state of RethinkDBCluster",
+				Description: "RethinkDBBackupEncryption defines the passphrase backup archives are encrypted with.",
 				Properties: map[string]spec.Schema{
-					"size": {
+					"keySecret": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Size is the number of Pods to create for the RethinkDB cluster. Default: 1",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "KeySecret is the name of the Secret with the passphrase. The archives are encrypted with AES-256-GCM, using a key derived from the passphrase.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"version": {
+					"key": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version is the RethinkDB version to use for the cluster.",
+							Description: "Key is the key of the passphrase in the Secret. Default: key",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"webAdminEnabled": {
+				},
+				Required: []string{"keySecret"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupQuiesce(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBackupQuiesce is the state of the writes to the cluster while the volume snapshots of a backup are taken.",
+				Properties: map[string]spec.Schema{
+					"tables": {
 						SchemaProps: spec.SchemaProps{
-							Description: "WebAdminEnabled indicates whether or not the Web Admin will be enabled for the cluster.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Tables are the tables whose writes are blocked until every snapshot has been created, in database.table form.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"pod": {
+					"writeHooks": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Pod defines the policy for pods owned by rethinkdb operator. This field cannot be updated once the CR is created.",
-							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPodPolicy"),
+							Description: "WriteHooks are the write hooks of the tables before their writes were blocked, which are restored when the writes resume.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableWriteHook"),
+									},
+								},
+							},
+						},
+					},
+					"resumeTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResumeTime is the time the writes resumed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
-				Required: []string{"size"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPodPolicy"},
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableWriteHook", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupRecord(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RethinkDBClusterStatus defines the observed state of RethinkDBCluster",
+				Description: "RethinkDBBackupRecord is the outcome of a single run of the backup.",
 				Properties: map[string]spec.Schema{
-					"servers": {
+					"jobName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Servers is a list of the names of the rethinkdb server Pods in the cluster.",
+							Description: "JobName is the name of the Job that took the backup.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the outcome of the backup.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the backup Job started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the backup Job finished.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the time it took to dump the cluster and write the archive.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"archive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Archive is the location of the archive, a path on the claim or an s3:// URL.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the size of the archive in bytes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tables": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tables are the tables included in the archive, in database.table form.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -125,13 +297,96 @@ func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterStatus(ref common.Refere
 							},
 						},
 					},
-					"serviceName": {
+					"snapshots": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ServiceName is the name of the Service for accessing the RethinkDB cluster.",
+							Description: "Snapshots are the VolumeSnapshots of the data volume claims of the servers, in server order, when the backup is taken with snapshots.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"quiesce": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quiesce is the state of the writes to the cluster while the snapshots are taken, when the backup is taken with snapshots.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupQuiesce"),
+						},
+					},
+					"pruned": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pruned are the archives or volume snapshots deleted by the retention policy after the backup.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable message with details about a failed backup, or about archives that could not be pruned.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"verification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verification is the outcome of the verification of the archive, when the backup is verified.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupVerification"),
+						},
+					},
+				},
+				Required: []string{"jobName", "phase"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupQuiesce", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupVerification", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupRetention(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBackupRetention defines which archives or volume snapshots of a RethinkDBBackup are kept. A backup is kept when any of the rules keeps it, all other backups are deleted after each successful backup.",
+				Properties: map[string]spec.Schema{
+					"keepLast": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepLast is the number of most recent archives to keep.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"keepDaily": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepDaily is the number of most recent days to keep the last archive of.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"keepWeekly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWeekly is the number of most recent weeks to keep the last archive of.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"keepMonthly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepMonthly is the number of most recent months to keep the last archive of.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
@@ -139,28 +394,2163 @@ func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterStatus(ref common.Refere
 	}
 }
 
-func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBPodPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RethinkDBPodPolicy defines the policy for pods owned by rethinkdb operator.",
+				Description: "RethinkDBBackupSpec defines the desired state of RethinkDBBackup",
 				Properties: map[string]spec.Schema{
-					"resources": {
+					"cluster": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Resources is the resource requirements for the containers.",
-							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+							Description: "Cluster references the RethinkDBCluster to back up. The cluster must be in the namespace of the backup, as the backup Job uses the admin and client Secrets of the cluster.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"),
 						},
 					},
-					"persistentVolumeClaimSpec": {
+					"schedule": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PersistentVolumeClaimSpec is the spec to describe PVC for the rethinkdb container This field is optional. If no PVC spec, rethinkdb container will use emptyDir as volume",
-							Ref:         ref("k8s.io/api/core/v1.PersistentVolumeClaimSpec"),
+							Description: "Schedule is a cron schedule to run the backup on, such as \"0 2 * * *\". A single backup is taken when no schedule is set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"databases": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Databases limits the backup to the given databases. Default: all databases",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tables": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tables limits the backup to the given tables, in database.table form. Default: all tables",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Storage is where the backup archives are written.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStorage"),
+						},
+					},
+					"retention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retention deletes the archives or volume snapshots that are no longer needed after each successful backup. All backups are kept when no retention is set.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupRetention"),
+						},
+					},
+					"verify": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verify restores each successful backup into a throwaway single-node RethinkDBCluster and compares the number of documents in each restored table with the archive.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the container image that runs rethinkdb dump. It must provide Python 3 with the packages of build/dump/requirements.txt. Default: jmckind/rethinkdb-operator-dump with the version of the operator",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"cluster", "storage"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimSpec", "k8s.io/api/core/v1.ResourceRequirements"},
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupRetention", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStorage", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBackupStatus defines the observed state of RethinkDBBackup",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase of the most recent backup.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable message when the backup can not be run.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastScheduleTime is the last time a scheduled backup was started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastSuccessfulTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSuccessfulTime is the last time a backup succeeded.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"backups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backups are the most recent backups, newest first.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupRecord"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupRecord", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupStorage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBackupStorage defines where backup archives are written. Exactly one of PersistentVolumeClaim, S3 or VolumeSnapshot must be set.",
+				Properties: map[string]spec.Schema{
+					"persistentVolumeClaim": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PersistentVolumeClaim is an existing claim the archives are written to.",
+							Ref:         ref("k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"),
+						},
+					},
+					"s3": {
+						SchemaProps: spec.SchemaProps{
+							Description: "S3 is an S3-compatible bucket the archives are uploaded to.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBS3Storage"),
+						},
+					},
+					"volumeSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeSnapshot takes a CSI VolumeSnapshot of the data volume claim of every server instead of running rethinkdb dump. Only clusters with a PersistentVolumeClaimSpec can be backed up with snapshots, and the snapshots can only be restored into a new cluster with the bootstrap of the cluster.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBVolumeSnapshotStorage"),
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Encryption encrypts the archives before they are written, and decrypts them when they are restored. The archives are not encrypted by default.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupEncryption"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupEncryption", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBS3Storage", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBVolumeSnapshotStorage", "k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBackupVerification(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBackupVerification is the outcome of the verification of a single backup.",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the outcome of the verification.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobName is the name of the Job that restored the archive.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the verification finished.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"tables": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tables are the verified tables, or the tables with a different number of documents when the verification failed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable message with details about the verification.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"phase"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBootstrapPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBootstrapPolicy defines the backup archive a new cluster is initialized from. Exactly one of Backup or Source must be set.",
+				Properties: map[string]spec.Schema{
+					"backup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backup is the name of a RethinkDBBackup in the namespace of the cluster, the most recent successful archive of the backup is restored. When the backup is taken with volume snapshots, the data volume claims of the servers are provisioned from the most recent successful snapshots instead.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source is the archive to restore when it was not taken by a RethinkDBBackup in the namespace of the cluster.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSource"),
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the container image that runs rethinkdb restore. Default: jmckind/rethinkdb-operator-dump with the version of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSource"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBBootstrapStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBBootstrapStatus defines the progress of the initialization of a new cluster from a backup archive.",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the current phase of the bootstrap.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"archive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Archive is the archive that is restored, or the backup Job that took the volume snapshots.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"snapshots": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Snapshots are the VolumeSnapshots the data volume claims of the servers are provisioned from, in server order, when the bootstrap backup was taken with snapshots.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobName is the name of the Job that restores the archive.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the restore Job started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the restore Job finished.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"tables": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tables are the restored tables, in database.table form.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable message with details about the bootstrap.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"phase"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCARotationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBCARotationStatus defines the progress of a rotation of the cluster CA.",
+				Properties: map[string]spec.Schema{
+					"stage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stage is the current stage of the rotation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is why the rotation was started, either Requested or Expiring.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"trigger": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Trigger is the value of the rotate-ca annotation when the rotation was started.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes what the current stage is waiting for, if anything.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the rotation was started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the rotation completed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"stage"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCertManagerPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBCertManagerPolicy defines the cert-manager Certificates created for the cluster.",
+				Properties: map[string]spec.Schema{
+					"issuerRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IssuerRef is the cert-manager Issuer or ClusterIssuer that issues the certificates.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBIssuerReference"),
+						},
+					},
+				},
+				Required: []string{"issuerRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBIssuerReference"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCertificateStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBCertificateStatus defines the state of a TLS certificate issued for the cluster.",
+				Properties: map[string]spec.Schema{
+					"secret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret is the name of the Secret holding the certificate.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"notAfter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NotAfter is the time the certificate expires.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes how an existing certificate differs from the certificate the operator would issue.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"secret", "notAfter"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClientCert(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBClientCert is the Schema for the rethinkdbclientcerts API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClientCertSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClientCertStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClientCertSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClientCertStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClientCertSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBClientCertSpec defines the desired state of RethinkDBClientCert",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster references the RethinkDBCluster whose CA issues the client certificate.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"),
+						},
+					},
+					"commonName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CommonName is the subject common name of the client certificate. Default: <namespace>.<name>",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretName is the name of the Secret the client certificate is stored in. Default: the name of the resource",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the lifetime of the client certificate. Default: the lifetime of the cluster certificates",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"renewBefore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenewBefore is how long before expiry the client certificate is renewed. Default: the renewal window of the cluster certificates",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClientCertStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBClientCertStatus defines the observed state of RethinkDBClientCert",
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest available observations of the state of the client certificate.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"),
+									},
+								},
+							},
+						},
+					},
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretName is the name of the Secret holding the client certificate.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serialNumber": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SerialNumber is the serial number of the issued client certificate.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"notAfter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NotAfter is the time the client certificate expires.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"renewalTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenewalTime is the time the client certificate will be renewed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBCluster is the Schema for the rethinkdbclusters API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBClusterReference references a RethinkDBCluster in the namespace of the referencing resource.",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the RethinkDBCluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBClusterSpec defines the desired state of RethinkDBCluster",
+				Properties: map[string]spec.Schema{
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the number of Pods to create for the RethinkDB cluster. Default: 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the RethinkDB version to use for the cluster. Changing the version of a running cluster upgrades the servers one at a time.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"webAdminEnabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WebAdminEnabled indicates whether or not the Web Admin will be enabled for the cluster.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"pod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pod defines the policy for pods owned by rethinkdb operator. This field cannot be updated once the CR is created.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPodPolicy"),
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS defines the policy for the TLS certificates issued for the cluster.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSPolicy"),
+						},
+					},
+					"clusterDomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterDomain is the DNS domain of the Kubernetes cluster, used for the server addresses and the names in the certificates. This field cannot be updated once the CR is created, changes are reverted to the domain in the status. Default: cluster.local",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bootstrap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bootstrap initializes a new cluster from a backup archive. The archive is restored once all servers are ready and the cluster is only reported as available once the restore has completed. This field only applies when the cluster is created.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBootstrapPolicy"),
+						},
+					},
+				},
+				Required: []string{"size"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBootstrapPolicy", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPodPolicy", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSPolicy"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBClusterStatus defines the observed state of RethinkDBCluster",
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the overall phase of the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest available observations of the state of the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"),
+									},
+								},
+							},
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation of the cluster spec observed by the operator.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"readyServers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyServers is the number of server Pods that are ready.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the RethinkDB version reported by the running servers.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"servers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Servers is a list of the names of the rethinkdb server Pods in the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"serviceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceName is the name of the Service for accessing the RethinkDB cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterDomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterDomain is the DNS domain of the Kubernetes cluster the servers were created with.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"drain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Drain is the progress of the server currently being drained before scaling down the cluster. This field is empty when no server is being drained.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDrainStatus"),
+						},
+					},
+					"upgrade": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Upgrade is the progress of the rolling upgrade to a new RethinkDB version. This field is empty when no upgrade is in progress.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUpgradeStatus"),
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS is the state of the TLS certificates issued for the cluster.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSStatus"),
+						},
+					},
+					"caRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CARotation is the progress of the last rotation of the cluster CA.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCARotationStatus"),
+						},
+					},
+					"adminPasswordRotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdminPasswordRotation is the progress of the last rotation of the admin password.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBAdminPasswordRotationStatus"),
+						},
+					},
+					"bootstrap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bootstrap is the progress of the initialization of the cluster from a backup archive. This field is empty when the cluster is not initialized from a backup.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBootstrapStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBAdminPasswordRotationStatus", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBootstrapStatus", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCARotationStatus", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDrainStatus", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSStatus", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUpgradeStatus"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBCondition describes the state of a resource at a certain point.",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the condition, one of True, False or Unknown.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastTransitionTime is the last time the condition transitioned from one status to another.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is a unique, one-word, CamelCase reason for the last transition of the condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable message with details about the last transition of the condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDatabase(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBDatabase is the Schema for the rethinkdbdatabases API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDatabaseSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDatabaseStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDatabaseSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBDatabaseStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDatabaseSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBDatabaseSpec defines the desired state of RethinkDBDatabase",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster references the RethinkDBCluster the database is created in.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"),
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the database. This field cannot be updated once the CR is created. Default: the name of the resource",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retentionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionPolicy determines whether the database and all of its tables are dropped when the resource is deleted, one of Retain or Delete. Default: Retain",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDatabaseStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBDatabaseStatus defines the observed state of RethinkDBDatabase",
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest available observations of the state of the database.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"),
+									},
+								},
+							},
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the database created in the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBDrainStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBDrainStatus defines the progress of draining a server prior to removing it from the cluster.",
+				Properties: map[string]spec.Schema{
+					"server": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Server is the name of the server Pod being drained.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the current phase of the drain.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the drain was started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"server", "phase"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBIndex(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBIndex defines a secondary index of a table.",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the index.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fields are the fields the index is built on. An index on more than one field is a compound index. Default: the field with the name of the index",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"multi": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Multi indexes each element of an array field separately.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"geo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Geo indexes a geospatial field.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBIssuerReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBIssuerReference references a cert-manager Issuer or ClusterIssuer.",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the issuer.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is the kind of the issuer, either Issuer or ClusterIssuer. Default: Issuer",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group is the API group of the issuer. Default: cert-manager.io",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBPermission(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBPermission grants permissions to a user globally, on a database or on a table. Permissions that are not set are inherited from the enclosing scope.",
+				Properties: map[string]spec.Schema{
+					"database": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Database is the database the permissions apply to. Permissions without a database apply globally.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"table": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Table is the table in the database the permissions apply to. Permissions without a table apply to the whole database.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"read": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Read allows the user to read data.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"write": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Write allows the user to modify data.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"connect": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Connect allows the user to open HTTP connections with r.http. Only valid for global permissions.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config allows the user to create, reconfigure and drop databases and tables.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBPodPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBPodPolicy defines the policy for pods owned by rethinkdb operator.",
+				Properties: map[string]spec.Schema{
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources is the resource requirements for the containers.",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"persistentVolumeClaimSpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PersistentVolumeClaimSpec is the spec to describe PVC for the rethinkdb container This field is optional. If no PVC spec, rethinkdb container will use emptyDir as volume",
+							Ref:         ref("k8s.io/api/core/v1.PersistentVolumeClaimSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.PersistentVolumeClaimSpec", "k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestore(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBRestore is the Schema for the rethinkdbrestores API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestoreSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBRestoreSource defines the backup archive that is restored.",
+				Properties: map[string]spec.Schema{
+					"archive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Archive is the name of the archive on the claim, or its key in the bucket. The archive location recorded in the status of a RethinkDBBackup can be used as is.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Storage is where the archive is read from.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStorage"),
+						},
+					},
+				},
+				Required: []string{"archive", "storage"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBBackupStorage"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestoreSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBRestoreSpec defines the desired state of RethinkDBRestore",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster references the RethinkDBCluster to restore the archive into. The cluster must be in the namespace of the restore, as the restore Job uses the admin and client Secrets of the cluster.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"),
+						},
+					},
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Source is the backup archive to restore.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSource"),
+						},
+					},
+					"databases": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Databases limits the restore to the given databases. Default: all databases in the archive",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tables": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tables limits the restore to the given tables, in database.table form. Default: all tables in the archive",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"force": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Force allows restoring into tables that already exist, documents with the same primary key are overwritten. The restore refuses to run when any of the restored tables exist otherwise.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"shards": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Shards overrides the number of shards of the restored tables. Default: the number of shards in the archive",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas overrides the number of replicas of the restored tables. Default: the number of replicas in the archive",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the container image that runs rethinkdb restore. It must provide Python 3 with the packages of build/dump/requirements.txt. Default: jmckind/rethinkdb-operator-dump with the version of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster", "source"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBRestoreSource"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBRestoreStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBRestoreStatus defines the observed state of RethinkDBRestore",
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest available observations of the state of the restore.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"),
+									},
+								},
+							},
+						},
+					},
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobName is the name of the Job that runs the restore.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the restore Job started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the restore Job finished.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the time it took to restore the archive.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"tables": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tables are the restored tables, or the existing tables that stopped the restore, in database.table form.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBS3Storage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBS3Storage defines an S3-compatible bucket that backup archives are uploaded to.",
+				Properties: map[string]spec.Schema{
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint is the URL of the S3-compatible service, such as a MinIO server. Default: AWS S3",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Region is the region of the bucket.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bucket": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bucket is the name of the bucket.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"prefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prefix is prepended to the name of the archives in the bucket.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecret is the name of the Secret with the accessKeyId and secretAccessKey keys used to access the bucket.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"bucket", "credentialsSecret"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTLSPolicy defines the policy for the TLS certificates issued for the cluster.",
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled turns TLS on or off for the cluster, driver and http ports. Default: true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ports": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ports turns TLS on or off for individual ports, overriding Enabled.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSPorts"),
+						},
+					},
+					"requireClientCertificates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequireClientCertificates requires clients of the driver port to present a certificate signed by the cluster CA, in addition to their password. Ignored when the driver port serves plaintext.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"renewBefore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenewBefore is how long before expiry the certificates are renewed. Default: 720h (30 days)",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"keyAlgorithm": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeyAlgorithm is the algorithm of the private keys generated for the CA and certificates. Default: RSA",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"keySize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeySize is the size of the private keys in bits. RSA keys may be 2048, 3072 or 4096 bits, ECDSA keys 256 bits (P-256) or 384 bits (P-384). Default: 2048 for RSA, 256 for ECDSA",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"caDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CADuration is the lifetime of the generated CA certificate. Default: 8760h (365 days)",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the lifetime of the cluster, driver, http and client certificates. Default: 8760h (365 days)",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"organization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Organization is the subject organization of the CA and certificates. Default: the namespace of the cluster",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"caSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CASecret is the name of an existing Secret with the CA certificate and private key used to sign the cluster certificates, instead of a generated self-signed CA. The optional ca.crt key holds the chain of the CA. When Secrets is set, only the CA certificate is required and it is used to verify the existing certificates.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secrets are existing TLS Secrets to mount instead of issuing certificates for the cluster.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSSecrets"),
+						},
+					},
+					"certManager": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CertManager creates cert-manager Certificates for the cluster, instead of issuing certificates in the operator. Ignored when Secrets is set.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCertManagerPolicy"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCertManagerPolicy", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSPorts", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTLSSecrets", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSPorts(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTLSPorts turns TLS on or off for each port of the servers. Unset ports follow the Enabled field of the TLS policy.",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster turns TLS on or off for connections between servers.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"driver": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Driver turns TLS on or off for client driver connections.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"http": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTP turns TLS on or off for the web-admin.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSSecrets(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTLSSecrets defines existing TLS Secrets to use for the cluster. The certificates are verified against the CA, but are never renewed or re-issued by the operator.",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the name of the Secret with the certificate for connections between servers.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"driver": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Driver is the name of the Secret with the certificate for client driver connections.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"http": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTP is the name of the Secret with the certificate for the web-admin.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"client": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Client is the name of the Secret with the client certificate the operator uses to connect to the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster", "driver", "http", "client"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTLSStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTLSStatus defines the state of the TLS certificates issued for the cluster.",
+				Properties: map[string]spec.Schema{
+					"caNotAfter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CANotAfter is the time the cluster CA certificate expires.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"certificates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Certificates is the state of each certificate used by the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCertificateStatus"),
+									},
+								},
+							},
+						},
+					},
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revision identifies the certificates the server Pods should be running with. Server Pods with a different revision are restarted one at a time.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCertificateStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTable(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTable is the Schema for the rethinkdbtables API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBTableStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTableSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTableSpec defines the desired state of RethinkDBTable",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster references the RethinkDBCluster the table is created in.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"),
+						},
+					},
+					"database": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Database is the name of the database the table is created in. The database must exist in the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the table. This field cannot be updated once the CR is created. Default: the name of the resource",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"primaryKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrimaryKey is the name of the primary key field. This field cannot be updated once the CR is created. Default: id",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"shards": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Shards is the number of shards of the table. Default: 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the number of replicas of each shard. Cannot be more than the number of servers in the cluster. Default: 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"durability": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Durability is whether writes are acknowledged once they are written to disk or to memory, one of hard or soft. Default: hard",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"writeAcks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WriteAcks is the number of replicas that must acknowledge a write, one of majority or single. Default: majority",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"indexes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indexes are the secondary indexes of the table. Indexes that are removed from the list are dropped.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBIndex"),
+									},
+								},
+							},
+						},
+					},
+					"retentionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionPolicy determines whether the table and its data are dropped when the resource is deleted, one of Retain or Delete. Default: Retain",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster", "database"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBIndex"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTableStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTableStatus defines the observed state of RethinkDBTable",
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest available observations of the state of the table.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"),
+									},
+								},
+							},
+						},
+					},
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID is the UUID of the table in the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the table created in the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"shards": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Shards is the observed number of shards of the table.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the observed number of replicas of each shard.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"indexes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indexes are the names of the secondary indexes of the table.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"allReplicasReady": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllReplicasReady is true when every replica of the table is ready, from the table_status system table.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"readyForReads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyForReads is true when the table can serve up-to-date reads, from the table_status system table.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"readyForWrites": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyForWrites is true when the table can accept writes, from the table_status system table.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBTableWriteHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBTableWriteHook is the write hook a table had before its writes were blocked.",
+				Properties: map[string]spec.Schema{
+					"table": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Table is the table in database.table form.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"function": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Function is the serialized hook function.",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+				},
+				Required: []string{"table", "function"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUpgradeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBUpgradeStatus defines the progress of a rolling upgrade of the servers to a new RethinkDB version.",
+				Properties: map[string]spec.Schema{
+					"targetVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetVersion is the RethinkDB version the servers are being upgraded to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the upgrade was started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"server": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Server is the name of the server Pod currently being upgraded. This field is empty between servers.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serverStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServerStartTime is the time the upgrade of the current server was started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"updatedServers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdatedServers is a list of the names of the server Pods that have been upgraded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paused indicates the upgrade has stopped because the current server failed to rejoin the cluster. The upgrade continues once the server recovers, or when the version is changed.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why the upgrade is paused.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"targetVersion"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUser(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBUser is the Schema for the rethinkdbusers API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUserSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUserStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUserSpec", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBUserStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUserSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBUserSpec defines the desired state of RethinkDBUser",
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster references the RethinkDBCluster the user is created in.",
+							Ref:         ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference"),
+						},
+					},
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Username is the name of the user account. This field cannot be updated once the CR is created. Default: the name of the resource",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"passwordSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordSecret is the name of the Secret with the password of the user in the password key. The Secret is created with a random password when it does not exist. Default: <name>-password",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"permissions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Permissions are the global, per-database and per-table permissions of the user. Permissions that are removed from the list are revoked.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPermission"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBClusterReference", "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBPermission"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBUserStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBUserStatus defines the observed state of RethinkDBUser",
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the latest available observations of the state of the user.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"),
+									},
+								},
+							},
+						},
+					},
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Username is the name of the user account created in the cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"passwordSecretVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordSecretVersion is the resource version of the password Secret last applied to the user account.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1.RethinkDBCondition"},
+	}
+}
+
+func schema_pkg_apis_rethinkdb_v1alpha1_RethinkDBVolumeSnapshotStorage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RethinkDBVolumeSnapshotStorage defines the CSI VolumeSnapshots taken of the data volume claims of the servers.",
+				Properties: map[string]spec.Schema{
+					"volumeSnapshotClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeSnapshotClassName is the name of the VolumeSnapshotClass of the snapshots. Default: the default class of the CSI driver",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
 	}
 }
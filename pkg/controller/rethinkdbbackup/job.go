@@ -0,0 +1,110 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"fmt"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// backupJobHistoryLimit is the number of finished Jobs kept by the CronJob of a scheduled backup.
+const backupJobHistoryLimit = 3
+
+// newBackupCronJob returns a CronJob that runs the backup Job on the schedule of the given RethinkDBBackup.
+func newBackupCronJob(backup *rethinkdbv1alpha1.RethinkDBBackup, cluster *rethinkdbv1alpha1.RethinkDBCluster) *batchv1beta1.CronJob {
+	historyLimit := int32(backupJobHistoryLimit)
+	return &batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: "batch/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backup.Name,
+			Namespace: backup.Namespace,
+			Labels:    defaultLabels(backup),
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   backup.Spec.Schedule,
+			ConcurrencyPolicy:          batchv1beta1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: &historyLimit,
+			FailedJobsHistoryLimit:     &historyLimit,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: defaultLabels(backup),
+				},
+				Spec: dump.NewBackupJobSpec(backup, cluster, defaultLabels(backup)),
+			},
+		},
+	}
+}
+
+// newBackupJob returns a Job that takes a single backup for the given RethinkDBBackup.
+func newBackupJob(backup *rethinkdbv1alpha1.RethinkDBBackup, cluster *rethinkdbv1alpha1.RethinkDBCluster) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backup.Name,
+			Namespace: backup.Namespace,
+			Labels:    defaultLabels(backup),
+		},
+		Spec: dump.NewBackupJobSpec(backup, cluster, defaultLabels(backup)),
+	}
+}
+
+// newVerifyCluster returns the throwaway single-node RethinkDBCluster the archives of the given RethinkDBBackup are
+// restored into, running the version of the given cluster that is backed up.
+func newVerifyCluster(backup *rethinkdbv1alpha1.RethinkDBBackup, cluster *rethinkdbv1alpha1.RethinkDBCluster) *rethinkdbv1alpha1.RethinkDBCluster {
+	return &rethinkdbv1alpha1.RethinkDBCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RethinkDBCluster",
+			APIVersion: rethinkdbv1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      verifyClusterName(backup),
+			Namespace: backup.Namespace,
+			Labels:    verifyLabels(backup),
+		},
+		Spec: rethinkdbv1alpha1.RethinkDBClusterSpec{
+			Size:    1,
+			Version: cluster.Spec.Version,
+		},
+	}
+}
+
+// newVerifyJob returns a Job that restores the archive of the given backup record into the given throwaway cluster
+// and compares the number of documents in each table with the archive.
+func newVerifyJob(backup *rethinkdbv1alpha1.RethinkDBBackup, record *rethinkdbv1alpha1.RethinkDBBackupRecord, cluster *rethinkdbv1alpha1.RethinkDBCluster) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-verify", record.JobName),
+			Namespace: backup.Namespace,
+			Labels:    verifyLabels(backup),
+		},
+		Spec: dump.NewVerifyJobSpec(backup, record.Archive, cluster, verifyLabels(backup)),
+	}
+}
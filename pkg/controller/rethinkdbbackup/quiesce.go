@@ -0,0 +1,139 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"fmt"
+	"sort"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// blockWrites blocks the writes to the tables of the given quiesce and then flushes the tables to disk, so the volume
+// snapshots taken next hold every acknowledged write. Tables that were dropped since are skipped.
+func blockWrites(ac admin.Client, quiesce *rethinkdbv1alpha1.RethinkDBBackupQuiesce) error {
+	tables, err := quiescedTables(ac, quiesce)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if err = ac.BlockWrites(table.DB, table.Name); err != nil {
+			return err
+		}
+	}
+	for _, table := range tables {
+		if err = ac.Sync(table.DB, table.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failSnapshots marks the given record as failed with the given message, once the writes to the cluster are resumed
+// with the given admin client when they are blocked.
+func failSnapshots(ac admin.Client, record *rethinkdbv1alpha1.RethinkDBBackupRecord, message string) (bool, error) {
+	if record.Quiesce != nil && record.Quiesce.ResumeTime == nil {
+		if err := resumeWrites(ac, record.Quiesce); err != nil {
+			return false, err
+		}
+	}
+	record.Phase = rethinkdbv1alpha1.BackupPhaseFailed
+	record.Message = message
+	return true, nil
+}
+
+// newQuiesce returns the quiesce of the writes to every table of the cluster, with the write hooks the tables have
+// before their writes are blocked. A write hook left by an earlier quiesce that did not complete is not recorded, so
+// the writes to that table resume without a write hook.
+func newQuiesce(ac admin.Client) (*rethinkdbv1alpha1.RethinkDBBackupQuiesce, error) {
+	configs, err := ac.TableConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	quiesce := &rethinkdbv1alpha1.RethinkDBBackupQuiesce{Tables: []string{}}
+	for _, config := range configs {
+		table := fmt.Sprintf("%s.%s", config.DB, config.Name)
+		quiesce.Tables = append(quiesce.Tables, table)
+
+		hook, err := ac.WriteHook(config.DB, config.Name)
+		if err != nil {
+			return nil, err
+		}
+		if hook != nil && !hook.BlocksWrites() {
+			quiesce.WriteHooks = append(quiesce.WriteHooks, rethinkdbv1alpha1.RethinkDBTableWriteHook{
+				Table:    table,
+				Function: hook.Function,
+			})
+		}
+	}
+	sort.Strings(quiesce.Tables)
+	return quiesce, nil
+}
+
+// quiescedTables returns the configuration of the tables of the given quiesce that still exist in the cluster.
+func quiescedTables(ac admin.Client, quiesce *rethinkdbv1alpha1.RethinkDBBackupQuiesce) ([]admin.TableConfig, error) {
+	configs, err := ac.TableConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	quiesced := map[string]bool{}
+	for _, table := range quiesce.Tables {
+		quiesced[table] = true
+	}
+
+	tables := []admin.TableConfig{}
+	for _, config := range configs {
+		if quiesced[fmt.Sprintf("%s.%s", config.DB, config.Name)] {
+			tables = append(tables, config)
+		}
+	}
+	return tables, nil
+}
+
+// resumeWrites restores the write hooks the tables of the given quiesce had before their writes were blocked, and
+// records the time the writes resumed. A write hook that was replaced while the writes were blocked is left as is.
+func resumeWrites(ac admin.Client, quiesce *rethinkdbv1alpha1.RethinkDBBackupQuiesce) error {
+	saved := map[string][]byte{}
+	for _, hook := range quiesce.WriteHooks {
+		saved[hook.Table] = hook.Function
+	}
+
+	tables, err := quiescedTables(ac, quiesce)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		hook, err := ac.WriteHook(table.DB, table.Name)
+		if err != nil {
+			return err
+		}
+		if !hook.BlocksWrites() {
+			continue
+		}
+		if err = ac.SetWriteHook(table.DB, table.Name, saved[fmt.Sprintf("%s.%s", table.DB, table.Name)]); err != nil {
+			return err
+		}
+	}
+
+	now := metav1.Now()
+	quiesce.ResumeTime = &now
+	return nil
+}
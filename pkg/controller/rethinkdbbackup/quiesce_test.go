@@ -0,0 +1,144 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin/fake"
+)
+
+// newTestAdminClient returns a fake admin client with the app.orders and app.audit tables, where app.orders has a
+// write hook of its own.
+func newTestAdminClient() *fake.Client {
+	return &fake.Client{
+		Databases: []string{"app"},
+		Tables: []admin.TableConfig{
+			{ID: "orders-id", DB: "app", Name: "orders"},
+			{ID: "audit-id", DB: "app", Name: "audit"},
+		},
+		WriteHooks: map[string]*admin.WriteHook{
+			"orders-id": {Function: []byte("orders-hook"), Query: "r.row"},
+		},
+	}
+}
+
+func TestQuiesceWrites(t *testing.T) {
+	ac := newTestAdminClient()
+
+	quiesce, err := newQuiesce(ac)
+	if err != nil {
+		t.Fatalf("newQuiesce: %v", err)
+	}
+	if want := []string{"app.audit", "app.orders"}; !reflect.DeepEqual(quiesce.Tables, want) {
+		t.Errorf("got quiesced tables %v, want %v", quiesce.Tables, want)
+	}
+	wantHooks := []rethinkdbv1alpha1.RethinkDBTableWriteHook{{Table: "app.orders", Function: []byte("orders-hook")}}
+	if !reflect.DeepEqual(quiesce.WriteHooks, wantHooks) {
+		t.Errorf("got saved write hooks %v, want %v", quiesce.WriteHooks, wantHooks)
+	}
+
+	if err = blockWrites(ac, quiesce); err != nil {
+		t.Fatalf("blockWrites: %v", err)
+	}
+	for _, id := range []string{"orders-id", "audit-id"} {
+		if !ac.WriteHooks[id].BlocksWrites() {
+			t.Errorf("expected the writes to table %s to be blocked", id)
+		}
+	}
+	synced := append([]string{}, ac.Synced...)
+	sort.Strings(synced)
+	if want := []string{"audit-id", "orders-id"}; !reflect.DeepEqual(synced, want) {
+		t.Errorf("got synced tables %v, want %v", synced, want)
+	}
+
+	if err = resumeWrites(ac, quiesce); err != nil {
+		t.Fatalf("resumeWrites: %v", err)
+	}
+	if hook := ac.WriteHooks["orders-id"]; hook == nil || string(hook.Function) != "orders-hook" {
+		t.Errorf("expected the write hook of app.orders to be restored, got %v", hook)
+	}
+	if hook, ok := ac.WriteHooks["audit-id"]; ok {
+		t.Errorf("expected the write hook of app.audit to be removed, got %v", hook)
+	}
+	if quiesce.ResumeTime == nil {
+		t.Error("expected the resume time to be recorded")
+	}
+}
+
+func TestQuiesceWritesChangedTables(t *testing.T) {
+	ac := newTestAdminClient()
+
+	// A blocking write hook left by an earlier quiesce that did not complete is not saved.
+	if err := ac.BlockWrites("app", "audit"); err != nil {
+		t.Fatalf("BlockWrites: %v", err)
+	}
+	quiesce, err := newQuiesce(ac)
+	if err != nil {
+		t.Fatalf("newQuiesce: %v", err)
+	}
+	for _, hook := range quiesce.WriteHooks {
+		if hook.Table == "app.audit" {
+			t.Errorf("expected the blocking write hook of app.audit not to be saved")
+		}
+	}
+
+	if err = blockWrites(ac, quiesce); err != nil {
+		t.Fatalf("blockWrites: %v", err)
+	}
+
+	// Replace the write hook of app.orders and drop app.audit while the writes are blocked.
+	if err = ac.SetWriteHook("app", "orders", []byte("new-hook")); err != nil {
+		t.Fatalf("SetWriteHook: %v", err)
+	}
+	ac.Tables = ac.Tables[:1]
+
+	if err = resumeWrites(ac, quiesce); err != nil {
+		t.Fatalf("resumeWrites: %v", err)
+	}
+	if hook := ac.WriteHooks["orders-id"]; hook == nil || string(hook.Function) != "new-hook" {
+		t.Errorf("expected the replaced write hook of app.orders to be left as is, got %v", hook)
+	}
+}
+
+func TestFailSnapshotsResumesWrites(t *testing.T) {
+	ac := newTestAdminClient()
+
+	quiesce, err := newQuiesce(ac)
+	if err != nil {
+		t.Fatalf("newQuiesce: %v", err)
+	}
+	if err = blockWrites(ac, quiesce); err != nil {
+		t.Fatalf("blockWrites: %v", err)
+	}
+
+	record := &rethinkdbv1alpha1.RethinkDBBackupRecord{Quiesce: quiesce}
+	if _, err = failSnapshots(ac, record, "snapshot failed"); err != nil {
+		t.Fatalf("failSnapshots: %v", err)
+	}
+	if record.Phase != rethinkdbv1alpha1.BackupPhaseFailed || record.Message != "snapshot failed" {
+		t.Errorf("got phase %s with message %q, want %s", record.Phase, record.Message, rethinkdbv1alpha1.BackupPhaseFailed)
+	}
+	if ac.WriteHooks["orders-id"].BlocksWrites() {
+		t.Error("expected the writes to app.orders to be resumed")
+	}
+	if quiesce.ResumeTime == nil {
+		t.Error("expected the resume time to be recorded")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"fmt"
+	"sort"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxBackupRecords is the number of backups kept in the status of a RethinkDBBackup.
+const maxBackupRecords = 10
+
+// isFinished returns true if the given backup has succeeded or failed.
+func isFinished(record *rethinkdbv1alpha1.RethinkDBBackupRecord) bool {
+	return record.Phase == rethinkdbv1alpha1.BackupPhaseSucceeded || record.Phase == rethinkdbv1alpha1.BackupPhaseFailed
+}
+
+// jobPhase returns the phase of the backup taken by the given Job.
+func jobPhase(job *batchv1.Job) rethinkdbv1alpha1.RethinkDBBackupPhase {
+	if dump.IsJobSucceeded(job) {
+		return rethinkdbv1alpha1.BackupPhaseSucceeded
+	}
+	if dump.IsJobFailed(job) {
+		return rethinkdbv1alpha1.BackupPhaseFailed
+	}
+	if job.Status.Active > 0 {
+		return rethinkdbv1alpha1.BackupPhaseRunning
+	}
+	return rethinkdbv1alpha1.BackupPhasePending
+}
+
+// newBackupRecord returns the record of the backup taken by the given Job, with the outcome read from the given Pods
+// of the Job once it has finished.
+func newBackupRecord(job *batchv1.Job, pods []corev1.Pod) rethinkdbv1alpha1.RethinkDBBackupRecord {
+	record := rethinkdbv1alpha1.RethinkDBBackupRecord{
+		JobName:        job.Name,
+		Phase:          jobPhase(job),
+		StartTime:      job.Status.StartTime,
+		CompletionTime: dump.JobFinishTime(job),
+	}
+	if !isFinished(&record) {
+		return record
+	}
+
+	result := dump.ReadResult(pods)
+	if result == nil {
+		return record
+	}
+	if result.Error != "" {
+		record.Message = result.Error
+		return record
+	}
+	record.Archive = result.Archive
+	record.Size = result.Size
+	record.Duration = result.Duration()
+	record.Tables = result.Tables
+	record.Pruned = result.Pruned
+	record.Message = result.Message
+	return record
+}
+
+// newVerification returns the outcome of the verification run by the given finished verify Job, as reported by the
+// given result, which may be nil when the Job did not report it.
+func newVerification(job *batchv1.Job, result *dump.Result) *rethinkdbv1alpha1.RethinkDBBackupVerification {
+	verification := &rethinkdbv1alpha1.RethinkDBBackupVerification{
+		Phase:          rethinkdbv1alpha1.VerificationPhaseVerified,
+		JobName:        job.Name,
+		CompletionTime: dump.JobFinishTime(job),
+	}
+	if result == nil {
+		result = &dump.Result{}
+	}
+	verification.Tables = result.Tables
+
+	if dump.IsJobSucceeded(job) {
+		verification.Message = fmt.Sprintf("restored %d tables with the same number of documents", len(result.Tables))
+		return verification
+	}
+	verification.Phase = rethinkdbv1alpha1.VerificationPhaseFailed
+	verification.Message = result.Error
+	if verification.Message == "" {
+		verification.Message = fmt.Sprintf("job %s failed", job.Name)
+	}
+	return verification
+}
+
+// pendingVerification returns the record of the given RethinkDBBackup to verify, the record being verified or else the
+// most recent successful backup that has not been verified yet, or nil if there is none. Archives that were pruned by
+// a later backup are not verified.
+func pendingVerification(backup *rethinkdbv1alpha1.RethinkDBBackup) *rethinkdbv1alpha1.RethinkDBBackupRecord {
+	pruned := map[string]bool{}
+	for _, record := range backup.Status.Backups {
+		for _, archive := range record.Pruned {
+			pruned[archive] = true
+		}
+	}
+
+	var pending *rethinkdbv1alpha1.RethinkDBBackupRecord
+	for i := range backup.Status.Backups {
+		record := &backup.Status.Backups[i]
+		if record.Verification != nil && record.Verification.Phase == rethinkdbv1alpha1.VerificationPhaseRunning {
+			return record
+		}
+		if pending == nil && record.Verification == nil && record.Phase == rethinkdbv1alpha1.BackupPhaseSucceeded &&
+			record.Archive != "" && !pruned[record.Archive] {
+			pending = record
+		}
+	}
+	return pending
+}
+
+// sortBackupRecords sorts the given records with the most recent backup first, backups that have not started yet
+// are sorted before all others.
+func sortBackupRecords(records []rethinkdbv1alpha1.RethinkDBBackupRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].StartTime == nil || records[j].StartTime == nil {
+			return records[i].StartTime == nil && records[j].StartTime != nil
+		}
+		return records[j].StartTime.Before(records[i].StartTime)
+	})
+}
+
+// serverClaims returns the data volume claims among the given claims of the servers of the given RethinkDBCluster,
+// in server order. The claims of ordinals beyond the size of the cluster belong to servers that are being drained or
+// were removed, so their data is not part of the cluster.
+func serverClaims(cluster *rethinkdbv1alpha1.RethinkDBCluster, claims []corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
+	byName := map[string]corev1.PersistentVolumeClaim{}
+	for _, claim := range claims {
+		byName[claim.Name] = claim
+	}
+
+	servers := []corev1.PersistentVolumeClaim{}
+	for ordinal := int32(0); ordinal < cluster.Spec.Size; ordinal++ {
+		name := fmt.Sprintf("%s-%s-%d", cluster.Name, rethinkdbcluster.RethinkDBDataSuffix, ordinal)
+		if claim, ok := byName[name]; ok {
+			servers = append(servers, claim)
+		}
+	}
+	return servers
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+)
+
+// retentionRule keeps the last backup of each of the given number of most recent periods.
+type retentionRule struct {
+	count  int32
+	period func(taken time.Time) string
+}
+
+// expiredBackups returns the names of the backup Jobs that are not kept by the given retention, given the time each
+// backup was taken, newest first. The backup with the given current name is always kept. The rules match those the
+// backup Job applies to the archives, so a backup is kept when any of the rules keeps it.
+func expiredBackups(retention *rethinkdbv1alpha1.RethinkDBBackupRetention, taken map[string]time.Time, current string) []string {
+	names := []string{}
+	for name := range taken {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if !taken[names[i]].Equal(taken[names[j]]) {
+			return taken[names[i]].After(taken[names[j]])
+		}
+		return names[i] > names[j]
+	})
+
+	keep := map[string]bool{current: true}
+	for i := 0; i < len(names) && i < int(retention.KeepLast); i++ {
+		keep[names[i]] = true
+	}
+
+	rules := []retentionRule{
+		{retention.KeepDaily, func(taken time.Time) string { return taken.Format("2006-01-02") }},
+		{retention.KeepWeekly, func(taken time.Time) string {
+			year, week := taken.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{retention.KeepMonthly, func(taken time.Time) string { return taken.Format("2006-01") }},
+	}
+	for _, rule := range rules {
+		periods := map[string]bool{}
+		for _, name := range names {
+			if int32(len(periods)) >= rule.count {
+				break
+			}
+			if period := rule.period(taken[name].UTC()); !periods[period] {
+				periods[period] = true
+				keep[name] = true
+			}
+		}
+	}
+
+	expired := []string{}
+	for _, name := range names {
+		if !keep[name] {
+			expired = append(expired, name)
+		}
+	}
+	return expired
+}
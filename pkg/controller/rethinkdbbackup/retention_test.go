@@ -0,0 +1,58 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+)
+
+func TestExpiredBackups(t *testing.T) {
+	// The backups are named in the order they were taken, newest first. The 4th of March 2019 is the Monday of ISO
+	// week 10, so b-2 and b-3 fall in week 9.
+	taken := map[string]time.Time{
+		"b-0": time.Date(2019, time.March, 4, 10, 0, 0, 0, time.UTC),
+		"b-1": time.Date(2019, time.March, 4, 2, 0, 0, 0, time.UTC),
+		"b-2": time.Date(2019, time.March, 3, 10, 0, 0, 0, time.UTC),
+		"b-3": time.Date(2019, time.February, 25, 10, 0, 0, 0, time.UTC),
+		"b-4": time.Date(2019, time.February, 10, 10, 0, 0, 0, time.UTC),
+		"b-5": time.Date(2019, time.January, 20, 10, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		retention rethinkdbv1alpha1.RethinkDBBackupRetention
+		current   string
+		want      []string
+	}{
+		{"keep last", rethinkdbv1alpha1.RethinkDBBackupRetention{KeepLast: 2}, "", []string{"b-2", "b-3", "b-4", "b-5"}},
+		{"keep daily", rethinkdbv1alpha1.RethinkDBBackupRetention{KeepDaily: 2}, "", []string{"b-1", "b-3", "b-4", "b-5"}},
+		{"keep weekly", rethinkdbv1alpha1.RethinkDBBackupRetention{KeepWeekly: 3}, "", []string{"b-1", "b-3", "b-5"}},
+		{"keep monthly", rethinkdbv1alpha1.RethinkDBBackupRetention{KeepMonthly: 2}, "", []string{"b-1", "b-2", "b-4", "b-5"}},
+		{"combined", rethinkdbv1alpha1.RethinkDBBackupRetention{KeepLast: 1, KeepMonthly: 3}, "", []string{"b-1", "b-2", "b-4"}},
+		{"current", rethinkdbv1alpha1.RethinkDBBackupRetention{KeepLast: 1}, "b-5", []string{"b-1", "b-2", "b-3", "b-4"}},
+		{"more than taken", rethinkdbv1alpha1.RethinkDBBackupRetention{KeepLast: 10}, "", []string{}},
+	}
+
+	for _, tt := range tests {
+		retention := tt.retention
+		if got := expiredBackups(&retention, taken, tt.current); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got expired backups %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
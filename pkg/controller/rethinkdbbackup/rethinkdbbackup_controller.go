@@ -0,0 +1,834 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/snapshot"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rethinkdbbackup")
+
+const (
+	// clusterRequeueDelay is the delay before checking whether the cluster is available again.
+	clusterRequeueDelay = time.Second * 10
+
+	// snapshotRequeueDelay is the delay before checking whether the volume snapshots are ready again.
+	snapshotRequeueDelay = time.Second * 10
+)
+
+// Reasons for the events recorded on a RethinkDBBackup.
+const (
+	reasonBackupFailed    = "BackupFailed"
+	reasonBackupSucceeded = "BackupSucceeded"
+	reasonClusterNotFound = "ClusterNotFound"
+	reasonClusterNotReady = "ClusterNotReady"
+	reasonCreated         = "Created"
+	reasonDeleted         = "Deleted"
+	reasonInvalidSpec     = "InvalidSpec"
+	reasonPruneFailed     = "PruneFailed"
+	reasonReconcileFailed = "ReconcileFailed"
+	reasonUpdated         = "Updated"
+	reasonVerified        = "BackupVerified"
+	reasonVerifyFailed    = "VerificationFailed"
+	reasonWritesBlocked   = "WritesBlocked"
+	reasonWritesResumed   = "WritesResumed"
+)
+
+// Add creates a new RethinkDBBackup Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRethinkDBBackup{
+		client:         mgr.GetClient(),
+		scheme:         mgr.GetScheme(),
+		recorder:       mgr.GetRecorder("rethinkdbbackup-controller"),
+		newAdminClient: admin.NewClientForCluster,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rethinkdbbackup-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RethinkDBBackup
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBBackup{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the CronJobs of scheduled backups
+	err = c.Watch(&source.Kind{Type: &batchv1beta1.CronJob{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &rethinkdbv1alpha1.RethinkDBBackup{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to backup Jobs, including the Jobs created by the CronJob of a scheduled backup, to record
+	// the outcome of each backup.
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(requestsForJob),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the throwaway clusters the backups are verified with
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBCluster{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &rethinkdbv1alpha1.RethinkDBBackup{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBClusters and requeue the RethinkDBBackups of the cluster, so a backup is taken
+	// once the cluster is available.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBCluster{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: util.RequestsForCluster(mgr.GetClient(), &rethinkdbv1alpha1.RethinkDBBackupList{}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRethinkDBBackup{}
+
+// ReconcileRethinkDBBackup reconciles a RethinkDBBackup object
+type ReconcileRethinkDBBackup struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+
+	// newAdminClient opens admin connections to the RethinkDB cluster.
+	newAdminClient admin.ClientFunc
+}
+
+// Reconcile ensures the Job or, for a scheduled backup, the CronJob exists for the given RethinkDBBackup request,
+// records the outcome of the backup Jobs in the status and verifies the successful backups when requested.
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileRethinkDBBackup) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("namespace", request.Namespace, "name", request.Name)
+	reqLogger.Info("reconciling RethinkDBBackup")
+
+	// Fetch the RethinkDBBackup instance
+	backup := &rethinkdbv1alpha1.RethinkDBBackup{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, backup)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// Keep a copy of the observed status, the status is only updated at the end of the reconcile if it has changed.
+	observed := backup.Status.DeepCopy()
+
+	result, err := r.reconcileBackup(backup)
+	if err == nil {
+		var recordsResult reconcile.Result
+		recordsResult, err = r.reconcileBackupRecords(backup)
+		if result.RequeueAfter == 0 {
+			result = recordsResult
+		}
+	}
+	if err == nil {
+		var verifyResult reconcile.Result
+		verifyResult, err = r.reconcileVerification(backup)
+		if result.RequeueAfter == 0 {
+			result = verifyResult
+		}
+	}
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile backup")
+		backup.Status.Message = err.Error()
+		r.recorder.Eventf(backup, corev1.EventTypeWarning, reasonReconcileFailed, "Unable to reconcile backup: %v", err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(observed, &backup.Status) {
+		if updateErr := r.client.Status().Update(context.TODO(), backup); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
+
+// backupJobs returns the backup Jobs of the given RethinkDBBackup.
+func (r *ReconcileRethinkDBBackup) backupJobs(backup *rethinkdbv1alpha1.RethinkDBBackup) ([]batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	opts := &client.ListOptions{
+		Namespace:     backup.Namespace,
+		LabelSelector: labels.SelectorFromSet(defaultLabels(backup)),
+	}
+	if err := r.client.List(context.TODO(), opts, jobs); err != nil {
+		return nil, err
+	}
+	return jobs.Items, nil
+}
+
+// deleteCronJob removes the CronJob of the given RethinkDBBackup if the backup is no longer scheduled.
+func (r *ReconcileRethinkDBBackup) deleteCronJob(backup *rethinkdbv1alpha1.RethinkDBBackup) error {
+	found := &batchv1beta1.CronJob{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: backup.Name, Namespace: backup.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(found, backup) {
+		return nil
+	}
+
+	log.Info("deleting backup cronjob", "namespace", found.Namespace, "name", found.Name)
+	if err = r.client.Delete(context.TODO(), found); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonDeleted, "Deleted cronjob %s", found.Name)
+	return nil
+}
+
+// deleteVerifyCluster removes the throwaway cluster of the given RethinkDBBackup. The cluster is deleted in the
+// foreground, so the next verification waits until the Pods of the previous cluster are gone.
+func (r *ReconcileRethinkDBBackup) deleteVerifyCluster(backup *rethinkdbv1alpha1.RethinkDBBackup) error {
+	found := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: verifyClusterName(backup), Namespace: backup.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(found, backup) || found.DeletionTimestamp != nil {
+		return nil
+	}
+
+	log.Info("deleting verify cluster", "namespace", found.Namespace, "name", found.Name)
+	err = r.client.Delete(context.TODO(), found, client.PropagationPolicy(metav1.DeletePropagationForeground))
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonDeleted, "Deleted cluster %s", found.Name)
+	return nil
+}
+
+// jobPods returns the Pods of the given backup Job.
+func (r *ReconcileRethinkDBBackup) jobPods(job *batchv1.Job) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	opts := &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{"job-name": job.Name}),
+	}
+	if err := r.client.List(context.TODO(), opts, pods); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// pruneSnapshots deletes the volume snapshots and the credentials Secrets of the backups of the given RethinkDBBackup
+// that are not kept by the retention, and records the deleted snapshots on the given record of the backup that just
+// succeeded. The snapshots of a backup are found by the Job label, and the backup was taken when the first of its
+// snapshots was created.
+func (r *ReconcileRethinkDBBackup) pruneSnapshots(backup *rethinkdbv1alpha1.RethinkDBBackup, record *rethinkdbv1alpha1.RethinkDBBackupRecord) error {
+	snapshots := snapshot.NewList()
+	opts := &client.ListOptions{
+		Namespace:     backup.Namespace,
+		LabelSelector: labels.SelectorFromSet(defaultLabels(backup)),
+	}
+	if err := r.client.List(context.TODO(), opts, snapshots); err != nil {
+		return err
+	}
+
+	taken := map[string]time.Time{}
+	jobSnapshots := map[string][]*unstructured.Unstructured{}
+	for i := range snapshots.Items {
+		snap := &snapshots.Items[i]
+		job, ok := snap.GetLabels()[jobLabelKey]
+		if !ok {
+			continue
+		}
+		created := snap.GetCreationTimestamp().Time
+		if first, ok := taken[job]; !ok || created.Before(first) {
+			taken[job] = created
+		}
+		jobSnapshots[job] = append(jobSnapshots[job], snap)
+	}
+
+	for _, job := range expiredBackups(backup.Spec.Retention, taken, record.JobName) {
+		for _, snap := range jobSnapshots[job] {
+			log.Info("deleting volume snapshot", "namespace", snap.GetNamespace(), "name", snap.GetName())
+			if err := r.client.Delete(context.TODO(), snap); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			record.Pruned = append(record.Pruned, snap.GetName())
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      snapshot.CredentialsSecretName(job),
+				Namespace: backup.Namespace,
+			},
+		}
+		log.Info("deleting snapshot credentials secret", "namespace", secret.Namespace, "name", secret.Name)
+		if err := r.client.Delete(context.TODO(), secret); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	sort.Strings(record.Pruned)
+	return nil
+}
+
+// reconcileBackup ensures the backup Job, or the CronJob for a scheduled backup, exists for the given
+// RethinkDBBackup.
+func (r *ReconcileRethinkDBBackup) reconcileBackup(backup *rethinkdbv1alpha1.RethinkDBBackup) (reconcile.Result, error) {
+	if invalid := validateBackup(backup); invalid != nil {
+		r.setMessage(backup, reasonInvalidSpec, invalid.Error())
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), util.ClusterName(backup), cluster)
+	if err != nil && errors.IsNotFound(err) {
+		// The cluster watch requeues the request once the cluster is created.
+		r.setMessage(backup, reasonClusterNotFound, fmt.Sprintf("RethinkDBCluster %s not found", util.ClusterName(backup)))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if backup.Spec.Storage.VolumeSnapshot != nil && (cluster.Spec.Pod == nil || cluster.Spec.Pod.PersistentVolumeClaimSpec == nil) {
+		r.setMessage(backup, reasonInvalidSpec, fmt.Sprintf("RethinkDBCluster %s has no data volume claims to snapshot", util.ClusterName(backup)))
+		return reconcile.Result{}, nil
+	}
+
+	if backup.Spec.Schedule != "" {
+		backup.Status.Message = ""
+		return reconcile.Result{}, r.reconcileCronJob(backup, cluster)
+	}
+
+	if err = r.deleteCronJob(backup); err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.reconcileJob(backup, cluster)
+}
+
+// reconcileBackupRecords records the outcome of the backup Jobs of the given RethinkDBBackup in the status. Backups
+// that have finished keep their record after the Job is removed, until they are among the oldest of the records.
+// The request is requeued while the volume snapshots of a backup are not ready.
+func (r *ReconcileRethinkDBBackup) reconcileBackupRecords(backup *rethinkdbv1alpha1.RethinkDBBackup) (reconcile.Result, error) {
+	jobs, err := r.backupJobs(backup)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	result := reconcile.Result{}
+
+	previous := map[string]rethinkdbv1alpha1.RethinkDBBackupRecord{}
+	for _, record := range backup.Status.Backups {
+		previous[record.JobName] = record
+	}
+
+	records := []rethinkdbv1alpha1.RethinkDBBackupRecord{}
+	for i := range jobs {
+		job := &jobs[i]
+		last, ok := previous[job.Name]
+		delete(previous, job.Name)
+		if ok && isFinished(&last) {
+			records = append(records, last)
+			continue
+		}
+
+		pods, err := r.jobPods(job)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		record := newBackupRecord(job, pods)
+		record.Quiesce = last.Quiesce
+		if backup.Spec.Storage.VolumeSnapshot != nil && record.Phase == rethinkdbv1alpha1.BackupPhaseSucceeded {
+			ready, err := r.reconcileSnapshots(backup, job, &record)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if !ready {
+				result = reconcile.Result{RequeueAfter: snapshotRequeueDelay}
+			}
+			if ready && record.Phase == rethinkdbv1alpha1.BackupPhaseSucceeded && backup.Spec.Retention != nil {
+				if err = r.pruneSnapshots(backup, &record); err != nil {
+					record.Message = fmt.Sprintf("unable to prune volume snapshots: %v", err)
+				}
+			}
+		}
+		records = append(records, record)
+		r.recordOutcome(backup, &record)
+	}
+
+	// Keep the records of finished backups whose Job has been removed. The writes blocked for a backup whose Job was
+	// removed before its volume snapshots were created are resumed.
+	for _, record := range previous {
+		if !isFinished(&record) && record.Quiesce != nil && record.Quiesce.ResumeTime == nil {
+			if err = r.resumeAbandonedWrites(backup, &record); err != nil {
+				return reconcile.Result{}, err
+			}
+			r.recordOutcome(backup, &record)
+		}
+		if isFinished(&record) {
+			records = append(records, record)
+		}
+	}
+
+	sortBackupRecords(records)
+	if len(records) > maxBackupRecords {
+		records = records[:maxBackupRecords]
+	}
+	if len(records) == 0 {
+		records = nil
+	}
+	backup.Status.Backups = records
+
+	if len(records) > 0 {
+		backup.Status.Phase = records[0].Phase
+	}
+	for _, record := range records {
+		if record.Phase != rethinkdbv1alpha1.BackupPhaseSucceeded || record.CompletionTime == nil {
+			continue
+		}
+		if backup.Status.LastSuccessfulTime == nil || backup.Status.LastSuccessfulTime.Before(record.CompletionTime) {
+			backup.Status.LastSuccessfulTime = record.CompletionTime.DeepCopy()
+		}
+	}
+	return result, nil
+}
+
+// reconcileCronJob ensures the CronJob for the given scheduled RethinkDBBackup exists and matches the spec.
+func (r *ReconcileRethinkDBBackup) reconcileCronJob(backup *rethinkdbv1alpha1.RethinkDBBackup, cluster *rethinkdbv1alpha1.RethinkDBCluster) error {
+	cronJob := newBackupCronJob(backup, cluster)
+	if err := controllerutil.SetControllerReference(backup, cronJob, r.scheme); err != nil {
+		return err
+	}
+
+	found := &batchv1beta1.CronJob{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: cronJob.Name, Namespace: cronJob.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("creating backup cronjob", "namespace", cronJob.Namespace, "name", cronJob.Name)
+		if err = r.client.Create(context.TODO(), cronJob); err != nil {
+			return err
+		}
+		r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonCreated, "Created cronjob %s with schedule %s", cronJob.Name, backup.Spec.Schedule)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	backup.Status.LastScheduleTime = found.Status.LastScheduleTime
+
+	// Fields defaulted by the API server are not set on the desired spec and are ignored in the comparison.
+	if apiequality.Semantic.DeepDerivative(cronJob.Spec, found.Spec) {
+		return nil
+	}
+
+	log.Info("updating backup cronjob", "namespace", found.Namespace, "name", found.Name)
+	found.Spec = cronJob.Spec
+	if err = r.client.Update(context.TODO(), found); err != nil {
+		return err
+	}
+	r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonUpdated, "Updated cronjob %s", found.Name)
+	return nil
+}
+
+// reconcileJob ensures the Job for the given single RethinkDBBackup exists. The Job is only created once the cluster
+// is available, so the backup does not fail while the cluster is starting.
+func (r *ReconcileRethinkDBBackup) reconcileJob(backup *rethinkdbv1alpha1.RethinkDBBackup, cluster *rethinkdbv1alpha1.RethinkDBCluster) (reconcile.Result, error) {
+	job := newBackupJob(backup, cluster)
+	if err := controllerutil.SetControllerReference(backup, job, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	found := &batchv1.Job{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, found)
+	if err == nil {
+		backup.Status.Message = ""
+		return reconcile.Result{}, nil
+	} else if !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	if !rethinkdbv1alpha1.IsConditionTrue(cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable) {
+		r.setMessage(backup, reasonClusterNotReady, fmt.Sprintf("RethinkDBCluster %s is not available", util.ClusterName(backup)))
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	}
+
+	log.Info("creating backup job", "namespace", job.Namespace, "name", job.Name)
+	if err = r.client.Create(context.TODO(), job); err != nil {
+		return reconcile.Result{}, err
+	}
+	backup.Status.Message = ""
+	r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonCreated, "Created job %s", job.Name)
+	return reconcile.Result{}, nil
+}
+
+// reconcileSnapshotCredentials copies the admin password of the given RethinkDBCluster for the volume snapshots taken
+// by the given backup Job, as the users of the cluster are restored with the snapshots. The Secret is not owned by the
+// backup, so it remains available as long as the snapshots.
+func (r *ReconcileRethinkDBBackup) reconcileSnapshotCredentials(backup *rethinkdbv1alpha1.RethinkDBBackup, job *batchv1.Job, cluster *rethinkdbv1alpha1.RethinkDBCluster) error {
+	name := snapshot.CredentialsSecretName(job.Name)
+	found := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: backup.Namespace}, found)
+	if err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+
+	source := &corev1.Secret{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: admin.AdminSecretName(cluster), Namespace: cluster.Namespace}, source)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: backup.Namespace,
+			Labels:    snapshotLabels(backup, job),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: source.Data,
+	}
+	log.Info("creating snapshot credentials secret", "namespace", secret.Namespace, "name", secret.Name)
+	if err = r.client.Create(context.TODO(), secret); err != nil {
+		return err
+	}
+	r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonCreated, "Created secret %s", secret.Name)
+	return nil
+}
+
+// reconcileSnapshots takes a VolumeSnapshot of the data volume claim of every server of the cluster once the given
+// backup Job has flushed the tables to disk, and records the snapshots on the given record. Only the claims of the
+// ordinals below the size of the cluster are snapshotted, so a cluster bootstrapped from the snapshots has that size.
+// The writes to every table are blocked from just before the snapshots are taken until every snapshot is created, so
+// the snapshots of all servers hold the same writes. The write hooks the tables had are recorded on the given record
+// before any of them is replaced, so they are restored even when the operator restarts in between. The backup only
+// succeeds once every snapshot is ready to use. Returns false while any of the snapshots is not ready.
+// The snapshots are not owned by the backup, so they are kept when the backup is removed.
+func (r *ReconcileRethinkDBBackup) reconcileSnapshots(backup *rethinkdbv1alpha1.RethinkDBBackup, job *batchv1.Job, record *rethinkdbv1alpha1.RethinkDBBackupRecord) (bool, error) {
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	if err := r.client.Get(context.TODO(), util.ClusterName(backup), cluster); err != nil {
+		return false, err
+	}
+
+	var ac admin.Client
+	if record.Quiesce == nil || record.Quiesce.ResumeTime == nil {
+		var err error
+		if ac, err = r.newAdminClient(r.client, cluster); err != nil {
+			return false, err
+		}
+		defer ac.Close()
+	}
+
+	claims := &corev1.PersistentVolumeClaimList{}
+	opts := &client.ListOptions{
+		Namespace: cluster.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			rethinkdbcluster.RethinkDBAppKey:     rethinkdbcluster.RethinkDBApp,
+			rethinkdbcluster.RethinkDBClusterKey: cluster.Name,
+		}),
+	}
+	if err := r.client.List(context.TODO(), opts, claims); err != nil {
+		return false, err
+	}
+	servers := serverClaims(cluster, claims.Items)
+	if int32(len(servers)) < cluster.Spec.Size {
+		return failSnapshots(ac, record, fmt.Sprintf("RethinkDBCluster %s has %d of %d data volume claims to snapshot", cluster.Name, len(servers), cluster.Spec.Size))
+	}
+
+	if err := r.reconcileSnapshotCredentials(backup, job, cluster); err != nil {
+		return false, err
+	}
+
+	// Record the write hooks of the tables before the writes are blocked.
+	if record.Quiesce == nil {
+		quiesce, err := newQuiesce(ac)
+		if err != nil {
+			return false, err
+		}
+		record.Quiesce = quiesce
+		record.Phase = rethinkdbv1alpha1.BackupPhaseRunning
+		return false, nil
+	}
+
+	found := map[string]*unstructured.Unstructured{}
+	record.Snapshots = []string{}
+	for _, claim := range servers {
+		name := fmt.Sprintf("%s-%s", job.Name, strings.TrimPrefix(claim.Name, cluster.Name+"-"))
+		record.Snapshots = append(record.Snapshots, name)
+
+		snap := &unstructured.Unstructured{}
+		snap.SetGroupVersionKind(snapshot.GVK)
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: backup.Namespace}, snap)
+		if err != nil && errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+
+		if message := snapshot.Error(snap); message != "" {
+			return failSnapshots(ac, record, fmt.Sprintf("volume snapshot %s failed: %s", name, message))
+		}
+		found[name] = snap
+	}
+
+	if len(found) < len(servers) {
+		if record.Quiesce.ResumeTime != nil {
+			return failSnapshots(ac, record, "volume snapshots were removed after the writes resumed")
+		}
+
+		if err := blockWrites(ac, record.Quiesce); err != nil {
+			return false, err
+		}
+		r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonWritesBlocked, "Blocked the writes to %d tables of cluster %s", len(record.Quiesce.Tables), cluster.Name)
+
+		for i, claim := range servers {
+			name := record.Snapshots[i]
+			if _, ok := found[name]; ok {
+				continue
+			}
+			desired := snapshot.New(name, backup.Namespace, claim.Name, cluster.Name,
+				backup.Spec.Storage.VolumeSnapshot.VolumeSnapshotClassName, snapshotLabels(backup, job))
+
+			log.Info("creating volume snapshot", "namespace", desired.GetNamespace(), "name", name)
+			if err := r.client.Create(context.TODO(), desired); err != nil {
+				return false, err
+			}
+			r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonCreated, "Created volume snapshot %s of %s", name, claim.Name)
+		}
+		record.Phase = rethinkdbv1alpha1.BackupPhaseRunning
+		return false, nil
+	}
+
+	created := true
+	ready := true
+	for _, snap := range found {
+		created = created && snapshot.IsCreated(snap)
+		ready = ready && snapshot.IsReady(snap)
+	}
+
+	if created && record.Quiesce.ResumeTime == nil {
+		if err := resumeWrites(ac, record.Quiesce); err != nil {
+			return false, err
+		}
+		r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonWritesResumed, "Resumed the writes to %d tables of cluster %s", len(record.Quiesce.Tables), cluster.Name)
+	}
+
+	if !ready {
+		record.Phase = rethinkdbv1alpha1.BackupPhaseRunning
+	}
+	return ready, nil
+}
+
+// reconcileVerification verifies the successful backups of the given RethinkDBBackup one at a time, newest first. The
+// archive is restored into a throwaway single-node cluster by a verify Job, and the cluster is deleted once the
+// outcome is recorded, so every archive is restored into an empty cluster.
+func (r *ReconcileRethinkDBBackup) reconcileVerification(backup *rethinkdbv1alpha1.RethinkDBBackup) (reconcile.Result, error) {
+	record := pendingVerification(backup)
+	if !backup.Spec.Verify || record == nil {
+		return reconcile.Result{}, r.deleteVerifyCluster(backup)
+	}
+	if record.Verification == nil {
+		record.Verification = &rethinkdbv1alpha1.RethinkDBBackupVerification{
+			Phase: rethinkdbv1alpha1.VerificationPhaseRunning,
+		}
+	}
+	verification := record.Verification
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: verifyClusterName(backup), Namespace: backup.Namespace}, cluster)
+	if err != nil && errors.IsNotFound(err) {
+		source := &rethinkdbv1alpha1.RethinkDBCluster{}
+		if err = r.client.Get(context.TODO(), util.ClusterName(backup), source); err != nil && !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+
+		cluster = newVerifyCluster(backup, source)
+		if err = controllerutil.SetControllerReference(backup, cluster, r.scheme); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		log.Info("creating verify cluster", "namespace", cluster.Namespace, "name", cluster.Name)
+		if err = r.client.Create(context.TODO(), cluster); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonCreated, "Created cluster %s to verify %s", cluster.Name, record.Archive)
+		verification.Message = fmt.Sprintf("waiting for cluster %s", cluster.Name)
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !metav1.IsControlledBy(cluster, backup) {
+		return reconcile.Result{}, fmt.Errorf("RethinkDBCluster %s is not owned by the backup", cluster.Name)
+	}
+	if cluster.DeletionTimestamp != nil || !rethinkdbv1alpha1.IsConditionTrue(cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable) {
+		verification.Message = fmt.Sprintf("waiting for cluster %s", cluster.Name)
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	}
+
+	job := newVerifyJob(backup, record, cluster)
+	found := &batchv1.Job{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		if err = controllerutil.SetControllerReference(backup, job, r.scheme); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		log.Info("creating verify job", "namespace", job.Namespace, "name", job.Name)
+		if err = r.client.Create(context.TODO(), job); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonCreated, "Created job %s to verify %s", job.Name, record.Archive)
+		verification.JobName = job.Name
+		verification.Message = fmt.Sprintf("restoring %s into cluster %s", record.Archive, cluster.Name)
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !metav1.IsControlledBy(found, backup) {
+		return reconcile.Result{}, fmt.Errorf("job %s is not owned by the backup", found.Name)
+	}
+	if !dump.IsJobSucceeded(found) && !dump.IsJobFailed(found) {
+		return reconcile.Result{}, nil
+	}
+
+	pods, err := r.jobPods(found)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	outcome := newVerification(found, dump.ReadResult(pods))
+
+	// The outcome is only recorded once the cluster is being deleted, so the next archive is not restored into it.
+	if err = r.deleteVerifyCluster(backup); err != nil {
+		return reconcile.Result{}, err
+	}
+	record.Verification = outcome
+	if outcome.Phase == rethinkdbv1alpha1.VerificationPhaseVerified {
+		r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonVerified, "Verified %s: %s", record.Archive, outcome.Message)
+	} else {
+		r.recorder.Eventf(backup, corev1.EventTypeWarning, reasonVerifyFailed, "Unable to verify %s: %s", record.Archive, outcome.Message)
+	}
+
+	log.Info("deleting verify job", "namespace", found.Namespace, "name", found.Name)
+	err = r.client.Delete(context.TODO(), found, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// recordOutcome records an event on the given RethinkDBBackup for the given backup once it has finished.
+func (r *ReconcileRethinkDBBackup) recordOutcome(backup *rethinkdbv1alpha1.RethinkDBBackup, record *rethinkdbv1alpha1.RethinkDBBackupRecord) {
+	switch record.Phase {
+	case rethinkdbv1alpha1.BackupPhaseSucceeded:
+		if len(record.Snapshots) > 0 {
+			r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonBackupSucceeded, "Backup %s took %d volume snapshots (%d tables), pruned %d volume snapshots",
+				record.JobName, len(record.Snapshots), len(record.Tables), len(record.Pruned))
+		} else {
+			r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonBackupSucceeded, "Backup %s wrote %s (%d bytes, %d tables), pruned %d archives",
+				record.JobName, record.Archive, record.Size, len(record.Tables), len(record.Pruned))
+		}
+		if record.Message != "" {
+			r.recorder.Eventf(backup, corev1.EventTypeWarning, reasonPruneFailed, "Backup %s: %s", record.JobName, record.Message)
+		}
+	case rethinkdbv1alpha1.BackupPhaseFailed:
+		r.recorder.Eventf(backup, corev1.EventTypeWarning, reasonBackupFailed, "Backup %s failed: %s", record.JobName, record.Message)
+	}
+}
+
+// resumeAbandonedWrites resumes the writes to the cluster of the given RethinkDBBackup that were blocked for the
+// given record, whose Job was removed before the volume snapshots were created, and marks the record as failed.
+func (r *ReconcileRethinkDBBackup) resumeAbandonedWrites(backup *rethinkdbv1alpha1.RethinkDBBackup, record *rethinkdbv1alpha1.RethinkDBBackupRecord) error {
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	if err := r.client.Get(context.TODO(), util.ClusterName(backup), cluster); err != nil {
+		return err
+	}
+
+	ac, err := r.newAdminClient(r.client, cluster)
+	if err != nil {
+		return err
+	}
+	defer ac.Close()
+
+	if _, err = failSnapshots(ac, record, fmt.Sprintf("job %s was removed before the volume snapshots were created", record.JobName)); err != nil {
+		return err
+	}
+	r.recorder.Eventf(backup, corev1.EventTypeNormal, reasonWritesResumed, "Resumed the writes to %d tables of cluster %s", len(record.Quiesce.Tables), cluster.Name)
+	return nil
+}
+
+// setMessage records why the given RethinkDBBackup can not be run with the given reason and message.
+func (r *ReconcileRethinkDBBackup) setMessage(backup *rethinkdbv1alpha1.RethinkDBBackup, reason string, message string) {
+	log.Info("backup not run", "namespace", backup.Namespace, "name", backup.Name, "reason", reason)
+	if backup.Status.Message != message {
+		r.recorder.Event(backup, corev1.EventTypeWarning, reason, message)
+	}
+	backup.Status.Message = message
+}
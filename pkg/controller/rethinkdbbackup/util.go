@@ -0,0 +1,110 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbbackup
+
+import (
+	"errors"
+	"fmt"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// backupLabelKey is the label on the Jobs and Pods of a RethinkDBBackup with the name of the backup.
+	backupLabelKey = "backup"
+
+	// jobLabelKey is the label on the volume snapshots and the credentials Secret of a backup taken with volume
+	// snapshots with the name of the backup Job, so the snapshots of a backup are pruned together.
+	jobLabelKey = "job-name"
+
+	// verifyLabelKey is the label on the throwaway cluster and the verify Jobs of a RethinkDBBackup with the name of
+	// the backup. The verify Jobs are not labeled as backup Jobs, so they are not recorded as backups.
+	verifyLabelKey = "verify"
+)
+
+// defaultLabels returns the default set of labels for the Jobs of the given RethinkDBBackup.
+func defaultLabels(backup *rethinkdbv1alpha1.RethinkDBBackup) map[string]string {
+	return map[string]string{
+		rethinkdbcluster.RethinkDBAppKey: rethinkdbcluster.RethinkDBApp,
+		backupLabelKey:                   backup.Name,
+	}
+}
+
+// requestsForJob returns a mapping from a backup or verify Job to the request for its RethinkDBBackup. Jobs of
+// scheduled backups are owned by the CronJob, so the backup is found through the label on the Job.
+func requestsForJob(obj handler.MapObject) []reconcile.Request {
+	name, ok := obj.Meta.GetLabels()[backupLabelKey]
+	if !ok {
+		name, ok = obj.Meta.GetLabels()[verifyLabelKey]
+	}
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: name, Namespace: obj.Meta.GetNamespace()},
+	}}
+}
+
+// snapshotLabels returns the labels for the volume snapshots and the credentials Secret taken by the given backup
+// Job of the given RethinkDBBackup.
+func snapshotLabels(backup *rethinkdbv1alpha1.RethinkDBBackup, job *batchv1.Job) map[string]string {
+	labels := defaultLabels(backup)
+	labels[jobLabelKey] = job.Name
+	return labels
+}
+
+// validateBackup returns an error if the spec of the given RethinkDBBackup can not be run.
+func validateBackup(backup *rethinkdbv1alpha1.RethinkDBBackup) error {
+	if err := util.ValidateClusterReference(backup); err != nil {
+		return err
+	}
+
+	if err := dump.ValidateTables(backup.Spec.Tables); err != nil {
+		return err
+	}
+	if err := dump.ValidateRetention(backup.Spec.Retention); err != nil {
+		return err
+	}
+	if backup.Spec.Storage.VolumeSnapshot != nil {
+		if len(backup.Spec.Databases) > 0 || len(backup.Spec.Tables) > 0 {
+			return errors.New("volumeSnapshot storage always includes all databases and tables")
+		}
+		if backup.Spec.Verify {
+			return errors.New("verify is not supported with volumeSnapshot storage")
+		}
+	}
+	return dump.ValidateStorage(&backup.Spec.Storage)
+}
+
+// verifyClusterName returns the name of the throwaway RethinkDBCluster the archives of the given RethinkDBBackup
+// are restored into to verify them.
+func verifyClusterName(backup *rethinkdbv1alpha1.RethinkDBBackup) string {
+	return fmt.Sprintf("%s-verify", backup.Name)
+}
+
+// verifyLabels returns the labels for the throwaway cluster and the verify Jobs of the given RethinkDBBackup.
+func verifyLabels(backup *rethinkdbv1alpha1.RethinkDBBackup) map[string]string {
+	return map[string]string{
+		rethinkdbcluster.RethinkDBAppKey: rethinkdbcluster.RethinkDBApp,
+		verifyLabelKey:                   backup.Name,
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbclientcert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rethinkdbclientcert")
+
+const (
+	// caRequeueDelay is the delay before checking whether the cluster CA is available again.
+	caRequeueDelay = time.Second * 10
+)
+
+// Reasons for the events recorded and the conditions set on a RethinkDBClientCert.
+const (
+	reasonCANotAvailable  = "CANotAvailable"
+	reasonCANotReady      = "CANotReady"
+	reasonClusterNotFound = "ClusterNotFound"
+	reasonInvalidSpec     = "InvalidSpec"
+	reasonIssued          = "Issued"
+	reasonIssueFailed     = "IssueFailed"
+	reasonReissued        = "Reissued"
+	reasonRenewed         = "Renewed"
+	reasonSecretConflict  = "SecretConflict"
+)
+
+// Add creates a new RethinkDBClientCert Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRethinkDBClientCert{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetRecorder("rethinkdbclientcert-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rethinkdbclientcert-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RethinkDBClientCert
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBClientCert{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to secondary resource Secret and requeue the owner RethinkDBClientCert
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &rethinkdbv1alpha1.RethinkDBClientCert{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBClusters and requeue the RethinkDBClientCerts that reference the cluster, so the
+	// certificates follow the cluster CA and trust bundle.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBCluster{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: util.RequestsForCluster(mgr.GetClient(), &rethinkdbv1alpha1.RethinkDBClientCertList{}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRethinkDBClientCert{}
+
+// ReconcileRethinkDBClientCert reconciles a RethinkDBClientCert object
+type ReconcileRethinkDBClientCert struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile issues the client certificate for the given RethinkDBClientCert request from the CA of the referenced
+// RethinkDBCluster, and renews it before it expires.
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileRethinkDBClientCert) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("namespace", request.Namespace, "name", request.Name)
+	reqLogger.Info("reconciling RethinkDBClientCert")
+
+	// Fetch the RethinkDBClientCert instance
+	cc := &rethinkdbv1alpha1.RethinkDBClientCert{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, cc)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected, so the Secret is removed with the RethinkDBClientCert.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// Keep a copy of the observed status, the status is only updated at the end of the reconcile if it has changed.
+	observed := cc.Status.DeepCopy()
+
+	result, err := r.reconcileClientCert(cc)
+	if err != nil {
+		reqLogger.Error(err, "unable to issue client certificate")
+		rethinkdbv1alpha1.SetCondition(&cc.Status.Conditions, rethinkdbv1alpha1.ClientCertConditionReady, corev1.ConditionFalse, reasonIssueFailed, err.Error())
+		r.recorder.Eventf(cc, corev1.EventTypeWarning, reasonIssueFailed, "Unable to issue client certificate: %v", err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(observed, &cc.Status) {
+		if updateErr := r.client.Status().Update(context.TODO(), cc); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
+
+// reconcileClientCert ensures the Secret with the client certificate for the given RethinkDBClientCert is present and
+// valid, and holds the current trust bundle of the cluster. The request is requeued at the renewal time.
+func (r *ReconcileRethinkDBClientCert) reconcileClientCert(cc *rethinkdbv1alpha1.RethinkDBClientCert) (reconcile.Result, error) {
+	if invalid := validateClientCert(cc); invalid != nil {
+		r.setNotReady(cc, reasonInvalidSpec, invalid.Error())
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), util.ClusterName(cc), cluster)
+	if err != nil && errors.IsNotFound(err) {
+		// The cluster watch requeues the request once the cluster is created.
+		r.setNotReady(cc, reasonClusterNotFound, fmt.Sprintf("RethinkDBCluster %s not found", util.ClusterName(cc)))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !rdbtls.IssuesCertificates(cluster) {
+		r.setNotReady(cc, reasonCANotAvailable, fmt.Sprintf("RethinkDBCluster %s does not issue its own certificates", util.ClusterName(cc)))
+		return reconcile.Result{}, nil
+	}
+
+	caSecret := &corev1.Secret{}
+	name := types.NamespacedName{Name: rdbtls.CASecretName(cluster), Namespace: cluster.Namespace}
+	if err = r.client.Get(context.TODO(), name, caSecret); err != nil && errors.IsNotFound(err) {
+		r.setNotReady(cc, reasonCANotReady, fmt.Sprintf("CA secret %s not found", name))
+		return reconcile.Result{RequeueAfter: caRequeueDelay}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	caConfigMap := &corev1.ConfigMap{}
+	name = types.NamespacedName{Name: rdbtls.CAConfigMapName(cluster), Namespace: cluster.Namespace}
+	if err = r.client.Get(context.TODO(), name, caConfigMap); err != nil && errors.IsNotFound(err) {
+		r.setNotReady(cc, reasonCANotReady, fmt.Sprintf("CA configmap %s not found", name))
+		return reconcile.Result{RequeueAfter: caRequeueDelay}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+	bundle := []byte(caConfigMap.Data[rdbtls.CACertKey])
+
+	secret, err := r.reconcileSecret(cc, cluster, caSecret, bundle)
+	if err != nil || secret == nil {
+		return reconcile.Result{}, err
+	}
+
+	cert, err := rdbtls.ValidateClientCertificate(cluster, caSecret, secret, commonName(cc))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	duration := rdbtls.ClientCertificateDuration(cluster, cc.Spec.Duration)
+	renewal := rdbtls.ClientCertificateRenewalTime(cluster, cert, duration, cc.Spec.RenewBefore)
+
+	cc.Status.SecretName = secret.Name
+	cc.Status.SerialNumber = cert.SerialNumber.String()
+	cc.Status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+	cc.Status.RenewalTime = &metav1.Time{Time: renewal}
+	rethinkdbv1alpha1.SetCondition(&cc.Status.Conditions, rethinkdbv1alpha1.ClientCertConditionReady, corev1.ConditionTrue, reasonIssued,
+		fmt.Sprintf("Client certificate issued in secret %s", secret.Name))
+
+	return reconcile.Result{RequeueAfter: time.Until(renewal)}, nil
+}
+
+// reconcileSecret ensures the Secret with the client certificate for the given RethinkDBClientCert is present. The
+// certificate is re-issued when it is invalid, no longer signed by the cluster CA or due for renewal. The Secret is
+// returned upon success, or nil if the Secret belongs to another resource.
+func (r *ReconcileRethinkDBClientCert) reconcileSecret(cc *rethinkdbv1alpha1.RethinkDBClientCert, cluster *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret, bundle []byte) (*corev1.Secret, error) {
+	name := rdbtls.ClientCertSecretName(cc)
+	duration := rdbtls.ClientCertificateDuration(cluster, cc.Spec.Duration)
+
+	found := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cc.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("creating new secret", "secret", name)
+		certPEM, keyPEM, err := rdbtls.IssueClientCertificate(cluster, caSecret, commonName(cc), duration)
+		if err != nil {
+			return nil, err
+		}
+		secret := newClientCertSecret(cc, certPEM, keyPEM, bundle)
+
+		// Set RethinkDBClientCert instance as the owner and controller, so the Secret is removed with the resource
+		if err = controllerutil.SetControllerReference(cc, secret, r.scheme); err != nil {
+			return nil, err
+		}
+
+		if err = r.client.Create(context.TODO(), secret); err != nil {
+			return nil, err
+		}
+
+		r.recorder.Eventf(cc, corev1.EventTypeNormal, reasonIssued, "Issued client certificate in secret %s", name)
+		return secret, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Never overwrite a Secret that belongs to something else
+	if !metav1.IsControlledBy(found, cc) {
+		r.setNotReady(cc, reasonSecretConflict, fmt.Sprintf("Secret %s exists and is not owned by this resource", name))
+		return nil, nil
+	}
+
+	// Secret exists, re-issue the certificate if it is invalid or renew it if it is due for renewal
+	reason := ""
+	cert, invalid := rdbtls.ValidateClientCertificate(cluster, caSecret, found, commonName(cc))
+	if invalid != nil {
+		log.Info("re-issuing invalid client certificate", "secret", name, "error", invalid.Error())
+		reason = reasonReissued
+	} else if !time.Now().Before(rdbtls.ClientCertificateRenewalTime(cluster, cert, duration, cc.Spec.RenewBefore)) {
+		log.Info("renewing client certificate", "secret", name, "notAfter", cert.NotAfter)
+		reason = reasonRenewed
+	}
+
+	if reason != "" {
+		certPEM, keyPEM, err := rdbtls.IssueClientCertificate(cluster, caSecret, commonName(cc), duration)
+		if err != nil {
+			return nil, err
+		}
+		found.Data = newClientCertSecret(cc, certPEM, keyPEM, bundle).Data
+	} else if !bytes.Equal(found.Data[rdbtls.CACertKey], bundle) {
+		// Keep the trust bundle in step with the cluster, which holds both the old and new CA during a rotation
+		log.Info("updating trust bundle of client certificate", "secret", name)
+		found.Data[rdbtls.CACertKey] = bundle
+	} else {
+		log.Info("secret exists", "secret", name)
+		return found, nil
+	}
+
+	if err = r.client.Update(context.TODO(), found); err != nil {
+		return nil, err
+	}
+
+	switch reason {
+	case reasonReissued:
+		r.recorder.Eventf(cc, corev1.EventTypeNormal, reason, "Re-issued client certificate in secret %s: %v", name, invalid)
+	case reasonRenewed:
+		r.recorder.Eventf(cc, corev1.EventTypeNormal, reason, "Renewed client certificate in secret %s", name)
+	}
+	return found, nil
+}
+
+// setNotReady marks the given RethinkDBClientCert as not ready with the given reason and message.
+func (r *ReconcileRethinkDBClientCert) setNotReady(cc *rethinkdbv1alpha1.RethinkDBClientCert, reason string, message string) {
+	log.Info("client certificate not ready", "namespace", cc.Namespace, "name", cc.Name, "reason", reason)
+	rethinkdbv1alpha1.SetCondition(&cc.Status.Conditions, rethinkdbv1alpha1.ClientCertConditionReady, corev1.ConditionFalse, reason, message)
+}
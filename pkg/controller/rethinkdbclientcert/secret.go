@@ -0,0 +1,40 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbclientcert
+
+import (
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newClientCertSecret creates a new TLS secret with the given client certificate, private key and trust bundle for the
+// given RethinkDBClientCert.
+func newClientCertSecret(cc *rethinkdbv1alpha1.RethinkDBClientCert, certPEM []byte, keyPEM []byte, bundle []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rdbtls.ClientCertSecretName(cc),
+			Namespace: cc.Namespace,
+			Labels:    labelsForClientCert(cc),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+			rdbtls.CACertKey:        bundle,
+		},
+	}
+}
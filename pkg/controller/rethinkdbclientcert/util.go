@@ -0,0 +1,53 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbclientcert
+
+import (
+	"fmt"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+)
+
+const (
+	// clientCertLabel is the label with the name of the RethinkDBClientCert that owns a Secret.
+	clientCertLabel = "client-cert"
+)
+
+// commonName returns the subject common name of the client certificate for the given RethinkDBClientCert.
+func commonName(cc *rethinkdbv1alpha1.RethinkDBClientCert) string {
+	if cc.Spec.CommonName != "" {
+		return cc.Spec.CommonName
+	}
+	return fmt.Sprintf("%s.%s", cc.Namespace, cc.Name)
+}
+
+// labelsForClientCert returns the labels for the Secret of the given RethinkDBClientCert.
+func labelsForClientCert(cc *rethinkdbv1alpha1.RethinkDBClientCert) map[string]string {
+	labels := map[string]string{
+		rethinkdbcluster.RethinkDBAppKey: rethinkdbcluster.RethinkDBApp,
+		clientCertLabel:                  cc.Name,
+	}
+	for key, val := range cc.ObjectMeta.Labels {
+		labels[key] = val
+	}
+	return labels
+}
+
+// validateClientCert returns an error if the spec of the given RethinkDBClientCert can not be issued.
+func validateClientCert(cc *rethinkdbv1alpha1.RethinkDBClientCert) error {
+	return util.ValidateClusterReference(cc)
+}
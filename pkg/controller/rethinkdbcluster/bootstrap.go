@@ -0,0 +1,100 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"fmt"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/snapshot"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// bootstrapJobName returns the name of the Job that restores the bootstrap archive for the given RethinkDBCluster.
+func bootstrapJobName(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, RethinkDBBootstrapKey)
+}
+
+// bootstrapLabels returns the labels for the bootstrap Job of the given RethinkDBCluster. The cluster label is not
+// set, so the Pods of the Job are not mistaken for server Pods.
+func bootstrapLabels(cr *v1alpha1.RethinkDBCluster) map[string]string {
+	return map[string]string{
+		RethinkDBAppKey:       RethinkDBApp,
+		RethinkDBBootstrapKey: cr.Name,
+	}
+}
+
+// bootstrapSnapshot returns the name of the VolumeSnapshot the data volume claim of the server with the given ordinal
+// is provisioned from, or an empty string if the claim is not provisioned from a snapshot.
+func bootstrapSnapshot(cr *v1alpha1.RethinkDBCluster, ordinal int32) string {
+	if !isSnapshotBootstrap(cr) || int(ordinal) >= len(cr.Status.Bootstrap.Snapshots) {
+		return ""
+	}
+	return cr.Status.Bootstrap.Snapshots[ordinal]
+}
+
+// isBootstrapping returns true if the given RethinkDBCluster is being initialized from a backup archive, the cluster
+// is not reported as available until the archive has been restored.
+func isBootstrapping(cr *v1alpha1.RethinkDBCluster) bool {
+	return cr.Spec.Bootstrap != nil && cr.Status.Bootstrap != nil && cr.Status.Bootstrap.Phase != v1alpha1.BootstrapPhaseCompleted
+}
+
+// isNewCluster returns true if the given RethinkDBCluster has not been available yet.
+func isNewCluster(cr *v1alpha1.RethinkDBCluster) bool {
+	available := v1alpha1.FindCondition(cr.Status.Conditions, v1alpha1.ClusterConditionAvailable)
+	return available == nil || available.Reason == reasonClusterCreating
+}
+
+// isSnapshotBootstrap returns true if the data volume claims of the given RethinkDBCluster are being provisioned from
+// volume snapshots.
+func isSnapshotBootstrap(cr *v1alpha1.RethinkDBCluster) bool {
+	return isBootstrapping(cr) && len(cr.Status.Bootstrap.Snapshots) > 0
+}
+
+// newBootstrapJob returns a Job that restores the given archive into the given RethinkDBCluster. The restore is
+// forced, so a failed restore can be retried into the tables it already created.
+func newBootstrapJob(cr *v1alpha1.RethinkDBCluster, source *v1alpha1.RethinkDBRestoreSource) *batchv1.Job {
+	spec := &v1alpha1.RethinkDBRestoreSpec{
+		Source: *source,
+		Force:  true,
+		Image:  cr.Spec.Bootstrap.Image,
+	}
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bootstrapJobName(cr),
+			Namespace: cr.Namespace,
+			Labels:    bootstrapLabels(cr),
+		},
+		Spec: dump.NewRestoreJobSpec(spec, cr, bootstrapLabels(cr)),
+	}
+}
+
+// restoredServerName returns the name of the server whose data volume claim the given VolumeSnapshot was taken of.
+// The second return value will be false if the snapshot was not taken of a server data volume claim.
+func restoredServerName(snap *unstructured.Unstructured) (string, bool) {
+	source := &v1alpha1.RethinkDBCluster{ObjectMeta: metav1.ObjectMeta{Name: snapshot.ClusterName(snap)}}
+	ordinal, ok := claimOrdinal(source, snapshot.ClaimName(snap))
+	if !ok {
+		return "", false
+	}
+	return serverName(serverPodName(source, ordinal)), true
+}
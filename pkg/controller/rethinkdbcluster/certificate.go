@@ -0,0 +1,116 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// certManagerGroup is the API group of the cert-manager resources.
+	certManagerGroup = "cert-manager.io"
+
+	// certManagerIssuerKind is the default kind of the cert-manager issuer.
+	certManagerIssuerKind = "Issuer"
+)
+
+// certificateGVK is the group, version and kind of the cert-manager Certificate resource.
+// Certificates are handled as unstructured objects, so the operator does not depend on the cert-manager API.
+var certificateGVK = schema.GroupVersionKind{Group: certManagerGroup, Version: "v1", Kind: "Certificate"}
+
+// isCertificateReady returns true if the given cert-manager Certificate reports the Ready condition.
+func isCertificateReady(cert *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(cert.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if ok && condition["type"] == "Ready" {
+			return condition["status"] == string(corev1.ConditionTrue)
+		}
+	}
+	return false
+}
+
+// newCertificate creates a new cert-manager Certificate for the TLS Secret with the given suffix.
+// The Certificate matches the certificate the operator would issue, with the same Secret name and file layout.
+func newCertificate(cr *v1alpha1.RethinkDBCluster, suffix string) *unstructured.Unstructured {
+	name := rdbtls.SecretName(cr, suffix)
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certificateGVK)
+	cert.SetName(name)
+	cert.SetNamespace(cr.ObjectMeta.Namespace)
+	cert.SetLabels(labelsForCluster(cr))
+	cert.Object["spec"] = newCertificateSpec(cr, name, suffix)
+	return cert
+}
+
+// newCertificateSpec returns the spec of the cert-manager Certificate for the TLS Secret with the given name and
+// suffix.
+// The Secret is labelled with the cluster labels, so that the controller is notified when it is issued or renewed.
+func newCertificateSpec(cr *v1alpha1.RethinkDBCluster, name string, suffix string) map[string]interface{} {
+	issuer := cr.Spec.TLS.CertManager.IssuerRef
+	kind := issuer.Kind
+	if kind == "" {
+		kind = certManagerIssuerKind
+	}
+	group := issuer.Group
+	if group == "" {
+		group = certManagerGroup
+	}
+
+	labels := map[string]interface{}{}
+	for key, val := range labelsForCluster(cr) {
+		labels[key] = val
+	}
+
+	dnsNames := []interface{}{}
+	for _, dnsName := range certificateDNSNames(cr, suffix) {
+		dnsNames = append(dnsNames, dnsName)
+	}
+
+	organizations := []interface{}{}
+	for _, organization := range rdbtls.CertificateOrganization(cr) {
+		organizations = append(organizations, organization)
+	}
+
+	return map[string]interface{}{
+		"secretName": name,
+		"secretTemplate": map[string]interface{}{
+			"labels": labels,
+		},
+		"commonName": name,
+		"dnsNames":   dnsNames,
+		"subject": map[string]interface{}{
+			"organizations": organizations,
+		},
+		"usages":      []interface{}{"digital signature", "key encipherment", "client auth", "server auth"},
+		"duration":    rdbtls.CertificateDuration(cr).String(),
+		"renewBefore": rdbtls.RenewalWindow(rdbtls.RenewBefore(cr), rdbtls.CertificateDuration(cr)).String(),
+		"privateKey": map[string]interface{}{
+			"algorithm":      string(rdbtls.KeyAlgorithm(cr)),
+			"encoding":       "PKCS1",
+			"size":           int64(rdbtls.KeySize(cr)),
+			"rotationPolicy": "Always",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  issuer.Name,
+			"kind":  kind,
+			"group": group,
+		},
+	}
+}
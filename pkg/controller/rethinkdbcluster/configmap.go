@@ -22,13 +22,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// newCAConfigMap creates a new ConfigMap for the given RethinkDBCluster and CA certificate.
-func newCAConfigMap(cr *v1alpha1.RethinkDBCluster, caSecret *corev1.Secret) (*corev1.ConfigMap, error) {
-	cm := newConfigMapWithSuffix(cr, "ca")
+// newCAConfigMap creates a new ConfigMap for the given RethinkDBCluster with the given bundle of trusted CA certificates.
+func newCAConfigMap(cr *v1alpha1.RethinkDBCluster, bundle []byte) *corev1.ConfigMap {
+	cm := newConfigMapWithSuffix(cr, RethinkDBCAKey)
 	cm.Data = map[string]string{
-		TLSCACertKey: string(caSecret.Data[TLSCertKey]),
+		TLSCACertKey: string(bundle),
 	}
-	return cm, nil
+	return cm
 }
 
 // newConfigMap creates a new ConfigMap for the given RethinkDBCluster.
@@ -0,0 +1,131 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"errors"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+)
+
+// drainTableConfig moves all replicas of the given table configuration away from the given server.
+// Replicas are replaced with the least loaded of the remaining servers, based on the given replica counts.
+// Returns true if the configuration was changed.
+func drainTableConfig(config *admin.TableConfig, server string, counts map[string]int) bool {
+	changed := false
+	for i := range config.Shards {
+		shard := &config.Shards[i]
+
+		if util.ContainsString(shard.NonvotingReplicas, server) {
+			shard.NonvotingReplicas = util.RemoveString(shard.NonvotingReplicas, server)
+			changed = true
+		}
+
+		if !util.ContainsString(shard.Replicas, server) {
+			continue
+		}
+		shard.Replicas = util.RemoveString(shard.Replicas, server)
+		counts[server]--
+		changed = true
+
+		// Find a replacement for the replica, preferring the server with the fewest replicas.
+		replacement := ""
+		for candidate, count := range counts {
+			if candidate == server || util.ContainsString(shard.Replicas, candidate) {
+				continue
+			}
+			if replacement == "" || count < counts[replacement] || (count == counts[replacement] && candidate < replacement) {
+				replacement = candidate
+			}
+		}
+		if replacement != "" {
+			shard.Replicas = append(shard.Replicas, replacement)
+			counts[replacement]++
+		}
+
+		if shard.PrimaryReplica == server && len(shard.Replicas) > 0 {
+			shard.PrimaryReplica = shard.Replicas[0]
+		}
+	}
+	return changed
+}
+
+// isServerDrained returns true when every table reports all replicas ready and no table has a replica
+// on the given server.
+func isServerDrained(ac admin.Client, server string) (bool, error) {
+	tables, err := ac.TableStatus()
+	if err != nil {
+		return false, err
+	}
+
+	for _, table := range tables {
+		if !table.Status.AllReplicasReady {
+			log.Info("waiting for table replicas to become ready", "db", table.DB, "table", table.Name)
+			return false, nil
+		}
+		for _, shard := range table.Shards {
+			for _, replica := range shard.Replicas {
+				if replica.Server == server {
+					log.Info("waiting for table to move off server", "db", table.DB, "table", table.Name, "server", server)
+					return false, nil
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+// reconfigureTablesAway updates the configuration of every table so that the given server holds no replicas.
+// The replicas are moved to the given remaining servers.
+func reconfigureTablesAway(ac admin.Client, server string, remaining []string) error {
+	if len(remaining) <= 0 {
+		return errors.New("no remaining servers to move table replicas to")
+	}
+
+	configs, err := ac.TableConfig()
+	if err != nil {
+		return err
+	}
+
+	// Count the replicas on each server to spread the moved replicas evenly.
+	counts := map[string]int{}
+	for _, name := range remaining {
+		counts[name] = 0
+	}
+	for _, config := range configs {
+		for _, shard := range config.Shards {
+			for _, replica := range shard.Replicas {
+				if _, ok := counts[replica]; ok {
+					counts[replica]++
+				}
+			}
+		}
+	}
+	counts[server] = 0
+
+	for i := range configs {
+		config := &configs[i]
+		if !drainTableConfig(config, server, counts) {
+			continue
+		}
+
+		log.Info("reconfiguring table", "db", config.DB, "table", config.Name, "server", server)
+		if err = ac.UpdateTableShards(config.ID, config.Shards); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDrainDestinations(t *testing.T) {
+	cr := &v1alpha1.RethinkDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec:       v1alpha1.RethinkDBClusterSpec{Size: 2},
+	}
+	servers := []corev1.Pod{}
+	for _, name := range []string{"example-0", "example-1", "example-2", "example-3"} {
+		servers = append(servers, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace}})
+	}
+
+	// The server with ordinal 2 is removed after the drained server, so it receives no replicas.
+	got := drainDestinations(cr, servers[3], servers)
+	if want := []string{"example_0", "example_1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("drainDestinations() = %v, want %v", got, want)
+	}
+}
+
+func TestDrainTableConfig(t *testing.T) {
+	config := &admin.TableConfig{
+		DB:   "app",
+		Name: "users",
+		Shards: []admin.Shard{
+			{PrimaryReplica: "example_3", Replicas: []string{"example_3", "example_0"}, NonvotingReplicas: []string{"example_3"}},
+			{PrimaryReplica: "example_0", Replicas: []string{"example_0"}},
+		},
+	}
+	counts := map[string]int{"example_0": 2, "example_1": 0, "example_3": 1}
+
+	if !drainTableConfig(config, "example_3", counts) {
+		t.Fatal("drainTableConfig() = false, want true")
+	}
+
+	// The replica moves to the least loaded server that does not hold one yet, which becomes the primary.
+	shard := config.Shards[0]
+	if want := []string{"example_0", "example_1"}; !reflect.DeepEqual(shard.Replicas, want) {
+		t.Errorf("replicas = %v, want %v", shard.Replicas, want)
+	}
+	if shard.PrimaryReplica != "example_0" {
+		t.Errorf("primary replica = %s, want example_0", shard.PrimaryReplica)
+	}
+	if len(shard.NonvotingReplicas) != 0 {
+		t.Errorf("nonvoting replicas = %v, want none", shard.NonvotingReplicas)
+	}
+	if counts["example_1"] != 1 {
+		t.Errorf("replica count of example_1 = %d, want 1", counts["example_1"])
+	}
+
+	if drainTableConfig(config, "example_3", counts) {
+		t.Error("drainTableConfig() = true for a drained server, want false")
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// repairCertificate reverts the labels and spec of the found cert-manager Certificate to the desired Certificate.
+// Returns the names of the fields that were reverted.
+func repairCertificate(found *unstructured.Unstructured, desired *unstructured.Unstructured) []string {
+	reverted := []string{}
+
+	labels := found.GetLabels()
+	changed := false
+	for key, value := range desired.GetLabels() {
+		if labels[key] != value {
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[key] = value
+			changed = true
+		}
+	}
+	if changed {
+		found.SetLabels(labels)
+		reverted = append(reverted, "labels")
+	}
+
+	if !reflect.DeepEqual(found.Object["spec"], desired.Object["spec"]) {
+		found.Object["spec"] = desired.Object["spec"]
+		reverted = append(reverted, "spec")
+	}
+	return reverted
+}
+
+// repairConfigMap reverts the managed fields of the found ConfigMap to the desired ConfigMap.
+// Returns the names of the fields that were reverted.
+func repairConfigMap(found *corev1.ConfigMap, desired *corev1.ConfigMap) []string {
+	reverted := repairLabels(&found.ObjectMeta, &desired.ObjectMeta)
+	if !reflect.DeepEqual(found.Data, desired.Data) {
+		found.Data = desired.Data
+		reverted = append(reverted, "data")
+	}
+	return reverted
+}
+
+// repairLabels restores the labels of the desired object that are missing or changed on the found object.
+// Additional labels on the found object are kept. Returns the names of the fields that were reverted.
+func repairLabels(found *metav1.ObjectMeta, desired *metav1.ObjectMeta) []string {
+	changed := false
+	for key, value := range desired.Labels {
+		if found.Labels[key] != value {
+			if found.Labels == nil {
+				found.Labels = map[string]string{}
+			}
+			found.Labels[key] = value
+			changed = true
+		}
+	}
+
+	if changed {
+		return []string{"labels"}
+	}
+	return []string{}
+}
+
+// repairService reverts the managed fields of the found Service to the desired Service.
+// Fields that are defaulted by the API server are only compared when set on the desired Service.
+// Returns the names of the fields that were reverted.
+func repairService(found *corev1.Service, desired *corev1.Service) []string {
+	reverted := repairLabels(&found.ObjectMeta, &desired.ObjectMeta)
+
+	if !servicePortsEqual(found.Spec.Ports, desired.Spec.Ports) {
+		found.Spec.Ports = desired.Spec.Ports
+		reverted = append(reverted, "ports")
+	}
+
+	if !reflect.DeepEqual(found.Spec.Selector, desired.Spec.Selector) {
+		found.Spec.Selector = desired.Spec.Selector
+		reverted = append(reverted, "selector")
+	}
+
+	desiredType := desired.Spec.Type
+	if desiredType == "" {
+		desiredType = corev1.ServiceTypeClusterIP
+	}
+	if found.Spec.Type != desiredType {
+		found.Spec.Type = desiredType
+		reverted = append(reverted, "type")
+	}
+
+	if found.Spec.PublishNotReadyAddresses != desired.Spec.PublishNotReadyAddresses {
+		found.Spec.PublishNotReadyAddresses = desired.Spec.PublishNotReadyAddresses
+		reverted = append(reverted, "publishNotReadyAddresses")
+	}
+
+	if desired.Spec.SessionAffinity != "" && found.Spec.SessionAffinity != desired.Spec.SessionAffinity {
+		found.Spec.SessionAffinity = desired.Spec.SessionAffinity
+		reverted = append(reverted, "sessionAffinity")
+	}
+
+	return reverted
+}
+
+// repairUserSecret restores the username of the found user Secret, based on the desired Secret.
+// The password can not be restored, as it is only known to the cluster; an error is returned if it is missing.
+// Returns the names of the fields that were reverted.
+func repairUserSecret(found *corev1.Secret, desired *corev1.Secret) ([]string, error) {
+	reverted := repairLabels(&found.ObjectMeta, &desired.ObjectMeta)
+
+	username := desired.Data[RethinkDBUsernameKey]
+	if string(found.Data[RethinkDBUsernameKey]) != string(username) {
+		if found.Data == nil {
+			found.Data = map[string][]byte{}
+		}
+		found.Data[RethinkDBUsernameKey] = username
+		reverted = append(reverted, fmt.Sprintf("data.%s", RethinkDBUsernameKey))
+	}
+
+	if len(found.Data[RethinkDBPasswordKey]) <= 0 {
+		return reverted, fmt.Errorf("secret %s is missing the %s key, it can not be restored", found.Name, RethinkDBPasswordKey)
+	}
+	return reverted, nil
+}
+
+// servicePortsEqual returns true if the found Service ports match the desired ports.
+// The target port and protocol are compared with their defaults when not set on the desired port.
+func servicePortsEqual(found []corev1.ServicePort, desired []corev1.ServicePort) bool {
+	if len(found) != len(desired) {
+		return false
+	}
+
+	for i := range desired {
+		want := desired[i]
+		if want.Protocol == "" {
+			want.Protocol = corev1.ProtocolTCP
+		}
+		if want.TargetPort == (intstr.IntOrString{}) {
+			want.TargetPort = intstr.FromInt(int(want.Port))
+		}
+
+		got := found[i]
+		if got.Name != want.Name || got.Port != want.Port || got.Protocol != want.Protocol || got.TargetPort != want.TargetPort {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+// Reasons for the events recorded on a RethinkDBCluster.
+const (
+	eventReasonAdminPasswordRotationCompleted = "AdminPasswordRotationCompleted"
+	eventReasonAdminPasswordRotationStarted   = "AdminPasswordRotationStarted"
+	eventReasonBootstrapCompleted             = "BootstrapCompleted"
+	eventReasonBootstrapFailed                = "BootstrapFailed"
+	eventReasonBootstrapStarted               = "BootstrapStarted"
+	eventReasonCARotationCompleted            = "CARotationCompleted"
+	eventReasonCARotationProgressing          = "CARotationProgressing"
+	eventReasonCARotationStarted              = "CARotationStarted"
+	eventReasonCertificateMismatch            = "CertificateMismatch"
+	eventReasonClusterDomainReverted          = "ClusterDomainReverted"
+	eventReasonCreated                        = "Created"
+	eventReasonDeleted                        = "Deleted"
+	eventReasonDrainCompleted                 = "DrainCompleted"
+	eventReasonDrainStarted                   = "DrainStarted"
+	eventReasonDriftReverted                  = "DriftReverted"
+	eventReasonDriftUnrepairable              = "DriftUnrepairable"
+	eventReasonIssuedCA                       = "IssuedCA"
+	eventReasonIssuedCert                     = "IssuedCertificate"
+	eventReasonReissuedCert                   = "ReissuedCertificate"
+	eventReasonRenewedCert                    = "RenewedCertificate"
+	eventReasonRestartingServer               = "RestartingServer"
+	eventReasonScalingDown                    = "ScalingDown"
+	eventReasonScalingUp                      = "ScalingUp"
+	eventReasonServerUpgraded                 = "ServerUpgraded"
+	eventReasonStatusUnreachable              = "StatusUnreachable"
+	eventReasonUpgradeCompleted               = "UpgradeCompleted"
+	eventReasonUpgradePaused                  = "UpgradePaused"
+	eventReasonUpgradeStarted                 = "UpgradeStarted"
+	eventReasonUpgradingServer                = "UpgradingServer"
+)
@@ -0,0 +1,131 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// certificateMismatches compares the certificate in the given existing TLS Secret with the certificate with the given
+// suffix the operator would issue for the given RethinkDBCluster. The certificate chain is verified against the given
+// CA bundle. Returns a description of each difference, or an empty slice if the certificate can be used for the cluster.
+func certificateMismatches(cr *v1alpha1.RethinkDBCluster, suffix string, secret *corev1.Secret, bundle []byte) []string {
+	cert, err := rdbtls.ValidateKeyPair(secret)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	mismatches := []string{}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(bundle)
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediateCertificates(secret.Data[corev1.TLSCertKey]),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		mismatches = append(mismatches, fmt.Sprintf("certificate chain is not trusted: %v", err))
+	}
+
+	// Certificates are used for both client and server auth, see newSignedCertificate.
+	if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		mismatches = append(mismatches, "key usage does not include digital signature")
+	}
+	for _, usage := range []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth} {
+		if !rdbtls.HasExtKeyUsage(cert, usage) {
+			mismatches = append(mismatches, fmt.Sprintf("extended key usage does not include %s", rdbtls.ExtKeyUsageName(usage)))
+		}
+	}
+
+	missing := []string{}
+	for _, name := range expectedHostnames(cr, suffix) {
+		if cert.VerifyHostname(name) != nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		mismatches = append(mismatches, fmt.Sprintf("subject alternative names do not include %s", strings.Join(missing, ", ")))
+	}
+	return mismatches
+}
+
+// expectedHostnames returns the host names the certificate with the given suffix must be valid for. The wildcard for
+// the server Pods is expanded to the address of each server, so certificates that list every server are accepted.
+func expectedHostnames(cr *v1alpha1.RethinkDBCluster, suffix string) []string {
+	hostnames := []string{}
+	for _, name := range certificateDNSNames(cr, suffix) {
+		if !strings.HasPrefix(name, "*.") {
+			hostnames = append(hostnames, name)
+			continue
+		}
+		for ordinal := int32(0); ordinal < cr.Spec.Size; ordinal++ {
+			hostnames = append(hostnames, serverAddress(cr, serverPodName(cr, ordinal)))
+		}
+	}
+	return hostnames
+}
+
+// intermediateCertificates returns a pool with the certificates following the first certificate in the given PEM
+// encoded chain.
+func intermediateCertificates(chain []byte) *x509.CertPool {
+	pool := x509.NewCertPool()
+	_, rest := pem.Decode(chain)
+	pool.AppendCertsFromPEM(rest)
+	return pool
+}
+
+// validateExternalCASecret returns an error if the given existing CA Secret can not be used for the given
+// RethinkDBCluster. A CA that signs certificates must include a private key the operator can sign with, while a CA
+// that only verifies existing certificates just needs the CA certificate.
+func validateExternalCASecret(cr *v1alpha1.RethinkDBCluster, secret *corev1.Secret) error {
+	if !rdbtls.IssuesCertificates(cr) {
+		cert, err := rdbtls.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", corev1.TLSCertKey, err)
+		}
+		if !cert.IsCA {
+			return errors.New("certificate is not a CA")
+		}
+		return nil
+	}
+
+	if err := rdbtls.ValidateCASecret(secret); err != nil {
+		return err
+	}
+	if _, err := rdbtls.ParsePEMEncodedPrivateKey(secret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+		return fmt.Errorf("invalid %s: %v", corev1.TLSPrivateKeyKey, err)
+	}
+
+	cert, err := rdbtls.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+	if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return errors.New("key usage does not include certificate signing")
+	}
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Errorf("certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
@@ -16,37 +16,65 @@ package rethinkdbcluster
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // generateCommand will generate the command for the container in a server Pod for the RethinkDBCluster.
-func generateCommand(cr *v1alpha1.RethinkDBCluster, peers []string) []string {
+// The given peers are the names of the existing server Pods to join.
+func generateCommand(cr *v1alpha1.RethinkDBCluster, name string, peers []string) []string {
 	// Add default args for all cases first
 	cmd := []string{
 		RethinkDBExePath,
 		"--bind", "all",
-		"--cluster-tls-ca", fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBCAKey),
-		"--cluster-tls-cert", fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBClusterKey),
-		"--cluster-tls-key", fmt.Sprintf("%s/%s.key", RethinkDBTLSPath, RethinkDBClusterKey),
+		"--canonical-address", serverAddress(cr, name),
 		"--directory", RethinkDBDataPath,
-		"--driver-tls-cert", fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBDriverKey),
-		"--driver-tls-key", fmt.Sprintf("%s/%s.key", RethinkDBTLSPath, RethinkDBDriverKey),
 		"--no-update-check",
+		"--server-name", serverName(name),
+	}
+
+	// Enable TLS for connections between servers if requested
+	if rdbtls.Enabled(cr, RethinkDBClusterKey) {
+		cmd = append(cmd, "--cluster-tls-ca")
+		cmd = append(cmd, fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBCAKey))
+
+		cmd = append(cmd, "--cluster-tls-cert")
+		cmd = append(cmd, fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBClusterKey))
+
+		cmd = append(cmd, "--cluster-tls-key")
+		cmd = append(cmd, fmt.Sprintf("%s/%s.key", RethinkDBTLSPath, RethinkDBClusterKey))
+	}
+
+	// Enable TLS for client driver connections if requested
+	if rdbtls.Enabled(cr, RethinkDBDriverKey) {
+		cmd = append(cmd, "--driver-tls-cert")
+		cmd = append(cmd, fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBDriverKey))
+
+		cmd = append(cmd, "--driver-tls-key")
+		cmd = append(cmd, fmt.Sprintf("%s/%s.key", RethinkDBTLSPath, RethinkDBDriverKey))
+
+		// Require clients to present a certificate signed by the cluster CA
+		if requiresClientCertificates(cr) {
+			cmd = append(cmd, "--driver-tls-ca")
+			cmd = append(cmd, fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBCAKey))
+		}
 	}
 
 	// Enable the http web-admin console if requested
-	if cr.Spec.WebAdminEnabled {
+	if !cr.Spec.WebAdminEnabled {
+		cmd = append(cmd, "--no-http-admin")
+	} else if rdbtls.Enabled(cr, RethinkDBHttpKey) {
 		cmd = append(cmd, "--http-tls-cert")
 		cmd = append(cmd, fmt.Sprintf("%s/%s.crt", RethinkDBTLSPath, RethinkDBHttpKey))
 
 		cmd = append(cmd, "--http-tls-key")
 		cmd = append(cmd, fmt.Sprintf("%s/%s.key", RethinkDBTLSPath, RethinkDBHttpKey))
-	} else {
-		cmd = append(cmd, "--no-http-admin")
 	}
 
 	// Handle initial password
@@ -59,27 +87,37 @@ func generateCommand(cr *v1alpha1.RethinkDBCluster, peers []string) []string {
 		// Join peers
 		for _, peer := range peers {
 			cmd = append(cmd, "--join")
-			cmd = append(cmd, fmt.Sprintf("%s:%d", peer, RethinkDBClusterPort))
+			cmd = append(cmd, fmt.Sprintf("%s:%d", serverAddress(cr, peer), RethinkDBClusterPort))
 		}
 	}
 
 	return cmd
 }
 
-// newContainers will create the Containers for the RethinkDB Pod.
-func newContainers(cr *v1alpha1.RethinkDBCluster, peers []string) []corev1.Container {
+// isPodReady returns true if the given Pod has a Ready condition with a status of True.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// newContainers will create the Containers for the RethinkDB Pod with the given name.
+func newContainers(cr *v1alpha1.RethinkDBCluster, name string, peers []string) []corev1.Container {
 	return []corev1.Container{{
-		Command: generateCommand(cr, peers),
+		Command: generateCommand(cr, name, peers),
 		Env: []corev1.EnvVar{{
 			Name: RethinkDBPasswordEnv,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, RethinkDBAdminKey)},
+					LocalObjectReference: corev1.LocalObjectReference{Name: admin.AdminSecretName(cr)},
 					Key:                  RethinkDBPasswordKey,
 				},
 			},
 		}},
-		Image: fmt.Sprintf("%s:%s", RethinkDBImage, cr.Spec.Version),
+		Image: serverImage(cr),
 		LivenessProbe: &corev1.Probe{
 			Handler: corev1.Handler{
 				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(RethinkDBDriverPort)},
@@ -89,15 +127,15 @@ func newContainers(cr *v1alpha1.RethinkDBCluster, peers []string) []corev1.Conta
 		Ports: []corev1.ContainerPort{
 			{
 				ContainerPort: RethinkDBClusterPort,
-				Name:          RethinkDBClusterKey,
+				Name:          portName(cr, RethinkDBClusterKey),
 			},
 			{
 				ContainerPort: RethinkDBDriverPort,
-				Name:          RethinkDBDriverKey,
+				Name:          portName(cr, RethinkDBDriverKey),
 			},
 			{
 				ContainerPort: RethinkDBHttpPort,
-				Name:          RethinkDBHttpKey,
+				Name:          portName(cr, RethinkDBHttpKey),
 			}},
 		ReadinessProbe: &corev1.Probe{
 			Handler: corev1.Handler{
@@ -128,22 +166,103 @@ func newContainerResources(cr *v1alpha1.RethinkDBCluster) corev1.ResourceRequire
 	return resources
 }
 
-// newPod returns a new Pod with the same namespace and name prefix as the cr
-func newPod(cr *v1alpha1.RethinkDBCluster, members []corev1.Pod) *corev1.Pod {
+// newPod returns a new server Pod for the given ordinal, with the same namespace and name prefix as the cr.
+// The Pod will join the given existing members of the cluster.
+func newPod(cr *v1alpha1.RethinkDBCluster, ordinal int32, members []corev1.Pod) *corev1.Pod {
+	name := serverPodName(cr, ordinal)
+
 	peers := []string{}
 	for _, member := range members {
-		peers = append(peers, member.Status.PodIP)
+		if member.Name != name {
+			peers = append(peers, member.Name)
+		}
+	}
+
+	annotations := map[string]string{}
+	if cr.Status.TLS != nil {
+		annotations[RethinkDBTLSRevisionAnnotation] = cr.Status.TLS.Revision
 	}
 
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: fmt.Sprintf("%s-", cr.ObjectMeta.Name),
-			Namespace:    cr.ObjectMeta.Namespace,
-			Labels:       labelsForCluster(cr),
+			Name:        name,
+			Namespace:   cr.ObjectMeta.Namespace,
+			Labels:      labelsForCluster(cr),
+			Annotations: annotations,
 		},
 		Spec: corev1.PodSpec{
-			Containers: newContainers(cr, peers),
-			Volumes:    newVolumes(cr),
+			Containers: newContainers(cr, name, peers),
+			Hostname:   name,
+			Subdomain:  clusterServiceName(cr),
+			Volumes:    newVolumes(cr, claimNameForOrdinal(cr, ordinal)),
 		},
 	}
 }
+
+// restartTarget returns the next server Pod to restart, or nil if all server Pods run the current certificates.
+// The stale server with the highest ordinal is restarted first.
+func restartTarget(cr *v1alpha1.RethinkDBCluster, servers []corev1.Pod) *corev1.Pod {
+	if cr.Status.TLS == nil {
+		return nil
+	}
+
+	var target *corev1.Pod
+	highest := int32(-1)
+	for i := range servers {
+		pod := &servers[i]
+		if pod.ObjectMeta.Annotations[RethinkDBTLSRevisionAnnotation] == cr.Status.TLS.Revision {
+			continue
+		}
+
+		ordinal, ok := serverOrdinal(cr, pod)
+		if !ok {
+			ordinal = -1
+		}
+		if target == nil || ordinal > highest {
+			target = pod
+			highest = ordinal
+		}
+	}
+	return target
+}
+
+// serversRestarted returns true if every known server Pod exists, is ready and runs the current certificates.
+func serversRestarted(cr *v1alpha1.RethinkDBCluster, servers []corev1.Pod) bool {
+	if len(servers) < len(cr.Status.Servers) || restartTarget(cr, servers) != nil {
+		return false
+	}
+
+	for i := range servers {
+		if !isPodReady(&servers[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// serverAddress returns the stable DNS name for the server Pod with the given name.
+func serverAddress(cr *v1alpha1.RethinkDBCluster, name string) string {
+	return fmt.Sprintf("%s.%s.%s.svc.%s", name, clusterServiceName(cr), cr.ObjectMeta.Namespace, cr.Spec.ClusterDomain)
+}
+
+// serverImage returns the container image for the server Pods, based on the requested version.
+func serverImage(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s:%s", RethinkDBImage, cr.Spec.Version)
+}
+
+// serverName returns the RethinkDB server name for the server Pod with the given name.
+// RethinkDB server names may only contain letters, numbers and underscores.
+func serverName(name string) string {
+	return strings.Replace(name, "-", "_", -1)
+}
+
+// serverOrdinal returns the ordinal for the given server Pod, based on the name of the Pod.
+// The second return value will be false if the Pod name does not contain a valid ordinal.
+func serverOrdinal(cr *v1alpha1.RethinkDBCluster, pod *corev1.Pod) (int32, bool) {
+	return parseOrdinal(pod.Name, fmt.Sprintf("%s-", cr.ObjectMeta.Name))
+}
+
+// serverPodName returns the name of the server Pod with the given ordinal.
+func serverPodName(cr *v1alpha1.RethinkDBCluster, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", cr.ObjectMeta.Name, ordinal)
+}
@@ -17,15 +17,27 @@ package rethinkdbcluster
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/snapshot"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -38,6 +50,26 @@ import (
 
 var log = logf.Log.WithName("controller_rethinkdbcluster")
 
+const (
+	// bootstrapRequeueDelay is the delay before checking whether the bootstrap archive can be restored again.
+	bootstrapRequeueDelay = time.Second * 10
+
+	// certificateRequeueDelay is the delay before checking whether cert-manager has issued the certificates again.
+	certificateRequeueDelay = time.Second * 10
+
+	// drainRequeueDelay is the delay before checking the progress of a server drain again.
+	drainRequeueDelay = time.Second * 10
+
+	// upgradeRequeueDelay is the delay before checking the progress of a server upgrade again.
+	upgradeRequeueDelay = time.Second * 10
+
+	// restartRequeueDelay is the delay before checking the progress of a server restart again.
+	restartRequeueDelay = time.Second * 10
+
+	// upgradeServerTimeout is the time a server has to rejoin the cluster after an upgrade before the upgrade is paused.
+	upgradeServerTimeout = time.Minute * 10
+)
+
 // Add creates a new RethinkDBCluster Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -46,7 +78,13 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileRethinkDBCluster{client: mgr.GetClient(), config: mgr.GetConfig(), scheme: mgr.GetScheme()}
+	return &ReconcileRethinkDBCluster{
+		client:         mgr.GetClient(),
+		config:         mgr.GetConfig(),
+		scheme:         mgr.GetScheme(),
+		recorder:       mgr.GetRecorder("rethinkdbcluster-controller"),
+		newAdminClient: admin.NewClientForCluster,
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -63,8 +101,8 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	// Watch for changes to secondary resource ConfigMap and requeue the owner RethinkDBCluster
-	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestForOwner{
+	// Watch for changes to secondary resource Job and requeue the owner RethinkDBCluster
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &rethinkdbv1alpha1.RethinkDBCluster{},
 	})
@@ -72,8 +110,8 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	// Watch for changes to secondary resource PersistentVolumeClaims and requeue the owner RethinkDBCluster
-	err = c.Watch(&source.Kind{Type: &corev1.PersistentVolumeClaim{}}, &handler.EnqueueRequestForOwner{
+	// Watch for changes to secondary resource ConfigMap and requeue the owner RethinkDBCluster
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &rethinkdbv1alpha1.RethinkDBCluster{},
 	})
@@ -81,6 +119,15 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to secondary resource PersistentVolumeClaims and requeue the RethinkDBCluster.
+	// Claims are not owned by the cluster, so the cluster label is used to find the RethinkDBCluster.
+	err = c.Watch(&source.Kind{Type: &corev1.PersistentVolumeClaim{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(requestsForClusterLabel),
+	})
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to secondary resource Pods and requeue the owner RethinkDBCluster
 	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
@@ -99,6 +146,24 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to existing TLS Secrets and requeue the RethinkDBCluster.
+	// Existing Secrets are not owned by the cluster, so the cluster label is used to find the RethinkDBCluster.
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(requestsForClusterLabel),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBClientCerts and requeue the referenced RethinkDBCluster, so a CA rotation moves on
+	// once every client certificate is re-issued from the new CA.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBClientCert{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(util.RequestsForReferencedCluster),
+	})
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to secondary resource Service and requeue the owner RethinkDBCluster
 	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
@@ -121,6 +186,12 @@ type ReconcileRethinkDBCluster struct {
 	client client.Client
 	config *rest.Config
 	scheme *runtime.Scheme
+
+	// recorder records Kubernetes events on the RethinkDBCluster for application teams without access to the logs.
+	recorder record.EventRecorder
+
+	// newAdminClient opens admin connections to the RethinkDB cluster, it may be replaced with a fake for testing.
+	newAdminClient admin.ClientFunc
 }
 
 // Reconcile compares the actual state of the cluster to the desired state
@@ -152,83 +223,167 @@ func (r *ReconcileRethinkDBCluster) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{Requeue: true}, r.client.Update(context.TODO(), cluster)
 	}
 
+	// Refuse changes to the cluster domain, the server addresses and certificates are based on the recorded domain
+	if domain := cluster.Status.ClusterDomain; domain != "" && cluster.Spec.ClusterDomain != domain {
+		reqLogger.Info("reverting change of cluster domain", "domain", domain, "requested", cluster.Spec.ClusterDomain)
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, eventReasonClusterDomainReverted,
+			"Reverted the cluster domain to %s, the cluster domain cannot be changed to %s", domain, cluster.Spec.ClusterDomain)
+		cluster.Spec.ClusterDomain = domain
+		return reconcile.Result{Requeue: true}, r.client.Update(context.TODO(), cluster)
+	}
+
+	// Keep a copy of the observed status, the status is only updated at the end of the reconcile if it has changed.
+	status := cluster.Status.DeepCopy()
+	cluster.Status.ClusterDomain = cluster.Spec.ClusterDomain
+
 	// Reconcile the cluster CA secret
 	caSecret, err := r.reconcileCASecret(cluster)
 	if err != nil {
 		reqLogger.Error(err, "unable to reconcile ca secret")
-		return reconcile.Result{}, err
+		rethinkdbv1alpha1.SetCondition(&cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionTLSReady, corev1.ConditionFalse, "CASecretFailed", err.Error())
+		return r.reconcileFailed(cluster, "CASecretFailed", err)
+	}
+
+	// Reconcile the cert-manager certificates, the TLS secrets are only used once every certificate is issued
+	if rdbtls.UsesCertManager(cluster) {
+		ready, err := r.reconcileCertificates(cluster)
+		if err != nil {
+			reqLogger.Error(err, "unable to reconcile certificates")
+			rethinkdbv1alpha1.SetCondition(&cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionTLSReady, corev1.ConditionFalse, "CertificatesFailed", err.Error())
+			return r.reconcileFailed(cluster, "CertificatesFailed", err)
+		}
+		if !ready {
+			reqLogger.Info("waiting for cert-manager to issue certificates...")
+			rethinkdbv1alpha1.SetCondition(&cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionTLSReady, corev1.ConditionFalse, reasonCertificatesPending,
+				"Waiting for cert-manager to issue the certificates")
+			return reconcile.Result{RequeueAfter: certificateRequeueDelay}, r.reconcileStatus(cluster, status)
+		}
 	}
 
 	// Reconcile the cluster CA configmap
-	err = r.reconcileCAConfigMap(cluster, caSecret)
+	bundle, err := r.reconcileCAConfigMap(cluster, caSecret)
 	if err != nil {
 		reqLogger.Error(err, "unable to reconcile ca configmap")
-		return reconcile.Result{}, err
+		rethinkdbv1alpha1.SetCondition(&cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionTLSReady, corev1.ConditionFalse, "CAConfigMapFailed", err.Error())
+		return r.reconcileFailed(cluster, "CAConfigMapFailed", err)
 	}
 
 	// Reconcile the admin service
 	err = r.reconcileAdminService(cluster)
 	if err != nil {
 		reqLogger.Error(err, "unable to reconcile admin service")
-		return reconcile.Result{}, err
+		return r.reconcileFailed(cluster, "AdminServiceFailed", err)
 	}
 
 	// Reconcile the driver service
 	err = r.reconcileDriverService(cluster)
 	if err != nil {
 		reqLogger.Error(err, "unable to reconcile driver service")
-		return reconcile.Result{}, err
+		return r.reconcileFailed(cluster, "DriverServiceFailed", err)
+	}
+
+	// Reconcile the headless cluster service
+	err = r.reconcileClusterService(cluster)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile cluster service")
+		return r.reconcileFailed(cluster, "ClusterServiceFailed", err)
 	}
 
 	// Reconcile the cluster TLS secrets
-	err = r.reconcileTLSSecrets(cluster, caSecret)
+	err = r.reconcileTLSSecrets(cluster, caSecret, bundle)
 	if err != nil {
 		reqLogger.Error(err, "unable to reconcile tls secrets")
-		return reconcile.Result{}, err
+		rethinkdbv1alpha1.SetCondition(&cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionTLSReady, corev1.ConditionFalse, "TLSSecretsFailed", err.Error())
+		return r.reconcileFailed(cluster, "TLSSecretsFailed", err)
+	}
+	setTLSCondition(cluster)
+
+	// Reconcile the rotation of the cluster CA
+	err = r.reconcileCARotation(cluster, caSecret)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile ca rotation")
+		return r.reconcileFailed(cluster, "CARotationFailed", err)
+	}
+
+	// Reconcile the volume snapshots the servers are provisioned from, the servers are only created once the
+	// snapshots are selected
+	hold, err := r.reconcileBootstrapSnapshots(cluster)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile bootstrap snapshots")
+		return r.reconcileFailed(cluster, "BootstrapFailed", err)
+	}
+	if hold {
+		reqLogger.Info("waiting for the volume snapshots to bootstrap the cluster from...")
+		return reconcile.Result{RequeueAfter: bootstrapRequeueDelay}, r.reconcileStatus(cluster, status)
 	}
 
 	// Reconcile the cluster admin secret
 	err = r.reconcileAdminSecret(cluster)
 	if err != nil {
 		reqLogger.Error(err, "unable to reconcile admin secret")
-		return reconcile.Result{}, err
+		return r.reconcileFailed(cluster, "AdminSecretFailed", err)
+	}
+
+	// Reconcile the rotation of the admin password
+	err = r.reconcileAdminPasswordRotation(cluster)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile admin password rotation")
+		return r.reconcileFailed(cluster, "AdminPasswordRotationFailed", err)
 	}
 
 	// Reconcile the cluster persistent volume claims
-	// err = r.reconcilePersistentVolumeClaims(cluster)
-	// if err != nil {
-	// 	reqLogger.Error(err, "unable to reconcile persistent volume claims")
-	// 	return reconcile.Result{}, err
-	// }
+	err = r.reconcilePersistentVolumeClaims(cluster)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile persistent volume claims")
+		return r.reconcileFailed(cluster, "PersistentVolumeClaimsFailed", err)
+	}
 
 	// Reconcile the cluster server pods
-	err = r.reconcileServerPods(cluster)
+	result, err := r.reconcileServerPods(cluster)
 	if err != nil {
 		reqLogger.Error(err, "unable to reconcile server pods")
+		return r.reconcileFailed(cluster, "ServerPodsFailed", err)
+	}
+
+	// Reconcile the restore of the bootstrap archive, the cluster is not available until the archive is restored
+	bootstrapResult, err := r.reconcileBootstrap(cluster)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile bootstrap")
+		return r.reconcileFailed(cluster, "BootstrapFailed", err)
+	}
+	if !result.Requeue && result.RequeueAfter == 0 {
+		result = bootstrapResult
+	}
+
+	// Reconcile the cluster status
+	err = r.reconcileStatus(cluster, status)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile cluster status")
 		return reconcile.Result{}, err
 	}
 
-	// No errors, return and don't requeue
-	return reconcile.Result{}, nil
+	// No errors, return and requeue only if the server pods are not yet reconciled
+	return result, nil
 }
 
-// addPVC will add a new Pod to the cluster.
-func (r *ReconcileRethinkDBCluster) addPVC(cr *rethinkdbv1alpha1.RethinkDBCluster) error {
-	log.Info("creating new persistent volume claim")
-	pvc := newPVC(cr)
-
-	// Set RethinkDB instance as the owner and controller
-	if err := controllerutil.SetControllerReference(cr, pvc, r.scheme); err != nil {
+// addPVC will add a new persistent volume claim to the cluster for the server with the given ordinal.
+// The claim is not owned by the RethinkDBCluster, it is only removed when the cluster is scaled down.
+func (r *ReconcileRethinkDBCluster) addPVC(cr *rethinkdbv1alpha1.RethinkDBCluster, ordinal int32) error {
+	pvc := newPVC(cr, ordinal)
+	log.Info("creating new persistent volume claim", "pvc", pvc.Name)
+	if err := r.client.Create(context.TODO(), pvc); err != nil {
 		return err
 	}
 
-	return r.client.Create(context.TODO(), newPVC(cr))
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created persistent volume claim %s", pvc.Name)
+	return nil
 }
 
-// addServer will add a new Pod to the cluster.
+// addServer will add a new Pod to the cluster, using the lowest available ordinal.
+// A server Pod that is re-created will use the same name, server name and persistent volume claim.
 func (r *ReconcileRethinkDBCluster) addServer(cr *rethinkdbv1alpha1.RethinkDBCluster, members []corev1.Pod) error {
-	log.Info("creating new server pod")
-	pod := newPod(cr, members)
+	pod := newPod(cr, nextServerOrdinal(cr, members), members)
+	log.Info("creating new server pod", "pod", pod.Name)
 
 	// Set RethinkDB instance as the owner and controller
 	if err := controllerutil.SetControllerReference(cr, pod, r.scheme); err != nil {
@@ -239,12 +394,187 @@ func (r *ReconcileRethinkDBCluster) addServer(cr *rethinkdbv1alpha1.RethinkDBClu
 	if err != nil {
 		return err
 	}
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created server pod %s", pod.Name)
+
+	// A server Pod that was deleted to restart it is already known.
+	if util.ContainsString(cr.Status.Servers, pod.Name) {
+		return nil
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonScalingUp, "Scaling up from %d to %d servers", len(members), cr.Spec.Size)
 
 	// Pod created successfully, update status and return
 	cr.Status.Servers = append(cr.Status.Servers, pod.Name)
 	return r.client.Status().Update(context.TODO(), cr)
 }
 
+// adminClientWithPasswords opens an admin connection to the given RethinkDBCluster with the first of the given
+// passwords that is accepted, as the password applied to the admin user is not known for certain while it is rotated.
+func (r *ReconcileRethinkDBCluster) adminClientWithPasswords(cr *rethinkdbv1alpha1.RethinkDBCluster, passwords ...[]byte) (admin.Client, error) {
+	err := fmt.Errorf("no admin password to connect to cluster %s with", cr.Name)
+	for _, psswd := range passwords {
+		if len(psswd) <= 0 {
+			continue
+		}
+
+		var ac admin.Client
+		if ac, err = r.newAdminClient(r.client, cr, admin.WithPassword(string(psswd))); err == nil {
+			return ac, nil
+		}
+	}
+	return nil, err
+}
+
+// bootstrapSource returns the archive to restore for the bootstrap of the given RethinkDBCluster. The most recent
+// successful archive of a RethinkDBBackup is chosen once, so a retried restore uses the same archive.
+func (r *ReconcileRethinkDBCluster) bootstrapSource(cr *rethinkdbv1alpha1.RethinkDBCluster) (*rethinkdbv1alpha1.RethinkDBRestoreSource, error) {
+	policy := cr.Spec.Bootstrap
+	if policy.Source != nil && policy.Backup != "" {
+		return nil, fmt.Errorf("bootstrap requires either a backup or a source, not both")
+	}
+	if policy.Source != nil {
+		if policy.Source.Archive == "" {
+			return nil, fmt.Errorf("bootstrap source requires an archive")
+		}
+		if err := dump.ValidateArchiveStorage(&policy.Source.Storage); err != nil {
+			return nil, err
+		}
+		return policy.Source.DeepCopy(), nil
+	}
+	if policy.Backup == "" {
+		return nil, fmt.Errorf("bootstrap requires a backup or a source")
+	}
+
+	backup := &rethinkdbv1alpha1.RethinkDBBackup{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: policy.Backup, Namespace: cr.Namespace}, backup)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := cr.Status.Bootstrap.Archive
+	for _, record := range backup.Status.Backups {
+		if archive != "" {
+			break
+		}
+		if record.Phase == rethinkdbv1alpha1.BackupPhaseSucceeded {
+			archive = record.Archive
+		}
+	}
+	if archive == "" {
+		return nil, fmt.Errorf("RethinkDBBackup %s has no successful backup", policy.Backup)
+	}
+
+	return &rethinkdbv1alpha1.RethinkDBRestoreSource{
+		Archive: archive,
+		Storage: *backup.Spec.Storage.DeepCopy(),
+	}, nil
+}
+
+// completeAdminPasswordRotation applies the new password from the admin rotation Secret to the admin user through
+// the users system table, and only then stores it in the admin Secret and records that the rotation completed. Each
+// step may be repeated, so the rotation resumes where it left off when it is interrupted.
+func (r *ReconcileRethinkDBCluster) completeAdminPasswordRotation(cr *rethinkdbv1alpha1.RethinkDBCluster, adminSecret *corev1.Secret, rotationSecret *corev1.Secret) error {
+	next := rotationSecret.Data[adminNextPasswordKey]
+	if len(next) > 0 {
+		ac, err := r.adminClientWithPasswords(cr, rotationSecret.Data[adminCurrentPasswordKey], next)
+		if err != nil {
+			return err
+		}
+		defer ac.Close()
+
+		log.Info("applying new admin password")
+		if err = ac.UpdateUserPassword(RethinkDBAdminKey, string(next)); err != nil {
+			return err
+		}
+
+		adminSecret.Data[RethinkDBPasswordKey] = next
+		if err = r.client.Update(context.TODO(), adminSecret); err != nil {
+			return err
+		}
+
+		rotationSecret.Data = map[string][]byte{adminCurrentPasswordKey: next}
+		if err = r.client.Update(context.TODO(), rotationSecret); err != nil {
+			return err
+		}
+	}
+
+	log.Info("admin password rotation completed")
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonAdminPasswordRotationCompleted, "Completed rotation of the admin password, the new password is in secret %s", adminSecret.Name)
+	now := metav1.Now()
+	rotation := cr.Status.AdminPasswordRotation
+	rotation.Stage = rethinkdbv1alpha1.AdminPasswordRotationCompleted
+	rotation.CompletionTime = &now
+	return r.client.Status().Update(context.TODO(), cr)
+}
+
+// completeSnapshotBootstrap renames the servers of the given RethinkDBCluster that were provisioned from volume
+// snapshots after the server Pods, as the names of the snapshotted servers are restored with their data, and
+// completes the bootstrap.
+func (r *ReconcileRethinkDBCluster) completeSnapshotBootstrap(cr *rethinkdbv1alpha1.RethinkDBCluster, ac admin.Client, servers []admin.ServerStatus) error {
+	status := cr.Status.Bootstrap
+	ids := map[string]string{}
+	for _, server := range servers {
+		ids[server.Name] = server.ID
+	}
+
+	for ordinal, name := range status.Snapshots {
+		found := &unstructured.Unstructured{}
+		found.SetGroupVersionKind(snapshot.GVK)
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found); err != nil {
+			return err
+		}
+
+		restored, ok := restoredServerName(found)
+		desired := serverName(serverPodName(cr, int32(ordinal)))
+		id, exists := ids[restored]
+		if !ok || !exists || restored == desired {
+			continue
+		}
+
+		log.Info("renaming restored server", "server", restored, "name", desired)
+		if err := ac.RenameServer(id, desired); err != nil {
+			return err
+		}
+	}
+
+	now := metav1.Now()
+	status.Phase = rethinkdbv1alpha1.BootstrapPhaseCompleted
+	status.CompletionTime = &now
+	status.Message = fmt.Sprintf("provisioned %d servers from the volume snapshots of %s", len(status.Snapshots), status.Archive)
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonBootstrapCompleted, "Provisioned %d servers from the volume snapshots of %s",
+		len(status.Snapshots), status.Archive)
+	return nil
+}
+
+// drainServer moves all table replicas away from the given server Pod, using an admin session to the cluster.
+// Returns true once the server no longer holds any replicas and all table replicas are ready.
+func (r *ReconcileRethinkDBCluster) drainServer(cr *rethinkdbv1alpha1.RethinkDBCluster, pod corev1.Pod, servers []corev1.Pod) (bool, error) {
+	ac, err := r.newAdminClient(r.client, cr)
+	if err != nil {
+		return false, err
+	}
+	defer ac.Close()
+
+	name := serverName(pod.Name)
+	if cr.Status.Drain.Phase == rethinkdbv1alpha1.DrainPhaseReconfiguring {
+		if err = reconfigureTablesAway(ac, name, drainDestinations(cr, pod, servers)); err != nil {
+			return false, err
+		}
+
+		cr.Status.Drain.Phase = rethinkdbv1alpha1.DrainPhaseWaiting
+		if err = r.client.Status().Update(context.TODO(), cr); err != nil {
+			return false, err
+		}
+	}
+
+	drained, err := isServerDrained(ac, name)
+	if err != nil || !drained {
+		return false, err
+	}
+
+	cr.Status.Drain.Phase = rethinkdbv1alpha1.DrainPhaseRemoving
+	return true, r.client.Status().Update(context.TODO(), cr)
+}
+
 // listPVCs will return a slice containing the persistent volume claims for the cluster.
 func (r *ReconcileRethinkDBCluster) listPVCs(cr *rethinkdbv1alpha1.RethinkDBCluster) ([]corev1.PersistentVolumeClaim, error) {
 	found := &corev1.PersistentVolumeClaimList{}
@@ -271,9 +601,144 @@ func (r *ReconcileRethinkDBCluster) listServers(cr *rethinkdbv1alpha1.RethinkDBC
 	return found.Items, nil
 }
 
+// pauseUpgrade pauses the upgrade in progress for the given RethinkDBCluster with the given message.
+// The upgrade is checked again after a delay, as it resumes once the current server recovers.
+func (r *ReconcileRethinkDBCluster) pauseUpgrade(cr *rethinkdbv1alpha1.RethinkDBCluster, message string) (reconcile.Result, error) {
+	result := reconcile.Result{RequeueAfter: upgradeRequeueDelay}
+	upgrade := cr.Status.Upgrade
+	if upgrade.Paused && upgrade.Message == message {
+		return result, nil
+	}
+
+	log.Info("pausing upgrade", "server", upgrade.Server, "message", message)
+	r.recorder.Event(cr, corev1.EventTypeWarning, eventReasonUpgradePaused, message)
+
+	upgrade.Paused = true
+	upgrade.Message = message
+	return result, r.client.Status().Update(context.TODO(), cr)
+}
+
+// pendingClientCertSecrets returns the names of the Secrets of the RethinkDBClientCerts that reference the given
+// RethinkDBCluster and do not hold a certificate signed by the CA in the given Secret.
+func (r *ReconcileRethinkDBCluster) pendingClientCertSecrets(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret) ([]string, error) {
+	caCert, err := rdbtls.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []string{}
+	for _, obj := range util.List(r.client, &rethinkdbv1alpha1.RethinkDBClientCertList{}) {
+		cluster := util.ClusterName(obj)
+		if cluster.Name != cr.Name || cluster.Namespace != cr.Namespace {
+			continue
+		}
+
+		name := rdbtls.ClientCertSecretName(obj.(*rethinkdbv1alpha1.RethinkDBClientCert))
+		secret := &corev1.Secret{}
+		err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: obj.GetNamespace()}, secret)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if err != nil || rdbtls.ValidateCertificateSecret(secret, caCert) != nil {
+			pending = append(pending, name)
+		}
+	}
+	return pending, nil
+}
+
+// queryClusterStatus returns the running RethinkDB version and the current issues for the given RethinkDBCluster,
+// using an admin session to the cluster.
+func (r *ReconcileRethinkDBCluster) queryClusterStatus(cr *rethinkdbv1alpha1.RethinkDBCluster) (string, []admin.Issue, error) {
+	ac, err := r.newAdminClient(r.client, cr)
+	if err != nil {
+		return "", nil, err
+	}
+	defer ac.Close()
+
+	servers, err := ac.ServerStatus()
+	if err != nil {
+		return "", nil, err
+	}
+
+	version := ""
+	if len(servers) > 0 {
+		version = parseServerVersion(servers[0].Process.Version)
+	}
+
+	issues, err := ac.CurrentIssues()
+	if err != nil {
+		return "", nil, err
+	}
+	return version, issues, nil
+}
+
+// reconcileAdminPasswordRotation rotates the admin password when the rotate-admin-password annotation changes or the
+// admin Secret is updated. The new password is generated and kept in the admin rotation Secret until it has been
+// applied to the cluster, as the cluster only uses the password from the admin Secret on first boot.
+func (r *ReconcileRethinkDBCluster) reconcileAdminPasswordRotation(cr *rethinkdbv1alpha1.RethinkDBCluster) error {
+	adminSecret := &corev1.Secret{}
+	name := admin.AdminSecretName(cr)
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, adminSecret)
+	if err != nil {
+		// The admin Secret may not be cached yet right after it is created
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	rotationSecret, err := r.reconcileAdminRotationSecret(cr, adminSecret)
+	if err != nil {
+		return err
+	}
+
+	// The new password is applied through an admin connection to the cluster
+	if !rethinkdbv1alpha1.IsConditionTrue(cr.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable) {
+		return nil
+	}
+
+	if isAdminPasswordRotating(cr) {
+		return r.completeAdminPasswordRotation(cr, adminSecret, rotationSecret)
+	}
+
+	reason := adminPasswordRotationReason(cr, adminSecret, rotationSecret)
+	if reason == "" {
+		return nil
+	}
+	return r.startAdminPasswordRotation(cr, rotationSecret, reason)
+}
+
+// reconcileAdminRotationSecret ensures the admin rotation Secret is present. The Secret is created with the password
+// from the given admin Secret, which is the password applied to the cluster before any rotation. The secret is
+// returned upon success.
+func (r *ReconcileRethinkDBCluster) reconcileAdminRotationSecret(cr *rethinkdbv1alpha1.RethinkDBCluster, adminSecret *corev1.Secret) (*corev1.Secret, error) {
+	name := adminRotationSecretName(cr)
+	found := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("creating new secret", "secret", name)
+		secret := newAdminRotationSecret(cr, adminSecret.Data[RethinkDBPasswordKey])
+
+		// Set RethinkDBCluster instance as the owner and controller
+		if err = controllerutil.SetControllerReference(cr, secret, r.scheme); err != nil {
+			return nil, err
+		}
+
+		if err = r.client.Create(context.TODO(), secret); err != nil {
+			return nil, err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created secret %s", name)
+		return secret, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
 // reconcileAdminSecret ensures the cluster admin user credentials are present.
 func (r *ReconcileRethinkDBCluster) reconcileAdminSecret(cr *rethinkdbv1alpha1.RethinkDBCluster) error {
-	name := fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, RethinkDBAdminKey)
+	name := admin.AdminSecretName(cr)
 	found := &corev1.Secret{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
@@ -283,18 +748,42 @@ func (r *ReconcileRethinkDBCluster) reconcileAdminSecret(cr *rethinkdbv1alpha1.R
 			return err
 		}
 
+		// The users are restored with the volume snapshots, so the admin password of the snapshotted cluster is used.
+		if isSnapshotBootstrap(cr) {
+			credentials := &corev1.Secret{}
+			credentialsName := snapshot.CredentialsSecretName(cr.Status.Bootstrap.Archive)
+			err = r.client.Get(context.TODO(), types.NamespacedName{Name: credentialsName, Namespace: cr.Namespace}, credentials)
+			if err != nil {
+				return err
+			}
+			secret.Data[RethinkDBPasswordKey] = credentials.Data[RethinkDBPasswordKey]
+		}
+
 		// Set RethinkDBCluster instance as the owner and controller
 		if err = controllerutil.SetControllerReference(cr, secret, r.scheme); err != nil {
 			return err
 		}
 
 		// Create the Secret and return
-		return r.client.Create(context.TODO(), secret)
+		if err = r.client.Create(context.TODO(), secret); err != nil {
+			return err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created secret %s", name)
+		return nil
 	} else if err != nil {
 		return err
 	}
-	log.Info("secret exists", "secret", found.Name)
-	return nil
+
+	// Secret exists, revert any changes to the username
+	desired := newSecret(cr)
+	desired.Data = map[string][]byte{RethinkDBUsernameKey: []byte(RethinkDBAdminKey)}
+	reverted, err := repairUserSecret(found, desired)
+	if err != nil {
+		log.Error(err, "unable to repair secret", "secret", found.Name)
+		r.recorder.Event(cr, corev1.EventTypeWarning, eventReasonDriftUnrepairable, err.Error())
+	}
+	return r.revertDrift(cr, found, "secret", found.Name, reverted)
 }
 
 // reconcileAdminService ensures the admin Service is created.
@@ -318,7 +807,12 @@ func (r *ReconcileRethinkDBCluster) reconcileAdminService(cr *rethinkdbv1alpha1.
 		}
 
 		// Create the Service and return
-		return r.client.Create(context.TODO(), svc)
+		if err = r.client.Create(context.TODO(), svc); err != nil {
+			return err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created service %s", name)
+		return nil
 	} else if err != nil {
 		return err
 	}
@@ -326,45 +820,351 @@ func (r *ReconcileRethinkDBCluster) reconcileAdminService(cr *rethinkdbv1alpha1.
 	// Service exists, verify that it should...
 	if !cr.Spec.WebAdminEnabled {
 		log.Info("removing existing service", "service", name)
-		return r.client.Delete(context.TODO(), found)
+		if err = r.client.Delete(context.TODO(), found); err != nil {
+			return err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonDeleted, "Deleted service %s, web admin is disabled", name)
+		return nil
 	}
 
-	log.Info("service exists", "service", found.Name)
-	return nil
+	return r.revertDrift(cr, found, "service", found.Name, repairService(found, newAdminService(cr)))
 }
 
-// reconcileCAConfigMap ensures the cluster CA certificate ConfigMap is present, based on the given CA Secret.
-func (r *ReconcileRethinkDBCluster) reconcileCAConfigMap(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret) error {
-	name := fmt.Sprintf("%s-ca", cr.Name)
-	found := &corev1.ConfigMap{}
+// reconcileBootstrap restores the bootstrap archive of the given new RethinkDBCluster. The first server is started
+// with the admin password from the admin Secret as usual, and the restore Job is created once all servers are ready
+// and have joined the cluster. A failed restore is retried when its Job is deleted.
+func (r *ReconcileRethinkDBCluster) reconcileBootstrap(cr *rethinkdbv1alpha1.RethinkDBCluster) (reconcile.Result, error) {
+	if cr.Spec.Bootstrap == nil {
+		return reconcile.Result{}, nil
+	}
 
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
-	if err != nil && errors.IsNotFound(err) {
-		log.Info("creating new configmap", "configmap", name)
-		cm, err := newCAConfigMap(cr, caSecret)
-		if err != nil {
-			return err
+	if cr.Status.Bootstrap == nil {
+		if !isNewCluster(cr) {
+			// The archive is never restored over the data of a cluster that has already been available.
+			return reconcile.Result{}, nil
 		}
-
-		// Set RethinkDBCluster instance as the owner and controller
-		if err = controllerutil.SetControllerReference(cr, cm, r.scheme); err != nil {
-			return err
+		cr.Status.Bootstrap = &rethinkdbv1alpha1.RethinkDBBootstrapStatus{
+			Phase:   rethinkdbv1alpha1.BootstrapPhaseWaiting,
+			Message: "waiting for all servers to become ready",
 		}
-
-		return r.client.Create(context.TODO(), cm)
-	} else if err != nil {
-		return err
+	}
+	status := cr.Status.Bootstrap
+	if status.Phase == rethinkdbv1alpha1.BootstrapPhaseCompleted {
+		return reconcile.Result{}, nil
 	}
 
-	log.Info("configmap exists", "configmap", found.Name)
-	return nil
-}
-
-// reconcileCASecret ensures the CA TLS Secret is created.
-// The secret is returned upon success to be used by other reconcilers.
-func (r *ReconcileRethinkDBCluster) reconcileCASecret(cr *rethinkdbv1alpha1.RethinkDBCluster) (*corev1.Secret, error) {
-	name := fmt.Sprintf("%s-ca", cr.Name)
-	found := &corev1.Secret{}
+	job := &batchv1.Job{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: bootstrapJobName(cr), Namespace: cr.Namespace}, job)
+	if err == nil {
+		if !metav1.IsControlledBy(job, cr) {
+			return reconcile.Result{}, fmt.Errorf("job %s is not owned by the cluster", job.Name)
+		}
+		return reconcile.Result{}, r.reconcileBootstrapJob(cr, job)
+	} else if !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	// Wait for all servers to be ready, the Pod watch requeues the request when a server becomes ready.
+	servers, err := r.listServers(cr)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	ready := int32(0)
+	for i := range servers {
+		if isPodReady(&servers[i]) {
+			ready++
+		}
+	}
+	if ready < cr.Spec.Size {
+		if !isSnapshotBootstrap(cr) {
+			// The servers provisioned from volume snapshots are already being restored.
+			status.Phase = rethinkdbv1alpha1.BootstrapPhaseWaiting
+		}
+		status.Message = fmt.Sprintf("%d of %d servers are ready", ready, cr.Spec.Size)
+		return reconcile.Result{}, nil
+	}
+
+	ac, err := r.newAdminClient(r.client, cr)
+	if err != nil {
+		status.Message = fmt.Sprintf("unable to connect to the cluster: %v", err)
+		return reconcile.Result{RequeueAfter: bootstrapRequeueDelay}, nil
+	}
+	joined, err := ac.ServerStatus()
+	if err != nil || int32(len(joined)) < cr.Spec.Size {
+		ac.Close()
+		status.Message = "waiting for all servers to join the cluster"
+		return reconcile.Result{RequeueAfter: bootstrapRequeueDelay}, nil
+	}
+	if isSnapshotBootstrap(cr) {
+		defer ac.Close()
+		return reconcile.Result{}, r.completeSnapshotBootstrap(cr, ac, joined)
+	}
+	ac.Close()
+
+	source, err := r.bootstrapSource(cr)
+	if err != nil {
+		log.Info("unable to find bootstrap archive", "error", err.Error())
+		status.Phase = rethinkdbv1alpha1.BootstrapPhaseWaiting
+		status.Message = fmt.Sprintf("unable to find the archive to restore: %v", err)
+		return reconcile.Result{RequeueAfter: bootstrapRequeueDelay}, nil
+	}
+
+	job = newBootstrapJob(cr, source)
+	if err = controllerutil.SetControllerReference(cr, job, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	log.Info("creating bootstrap job", "job", job.Name, "archive", source.Archive)
+	if err = r.client.Create(context.TODO(), job); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonBootstrapStarted, "Restoring archive %s with job %s", source.Archive, job.Name)
+
+	*status = rethinkdbv1alpha1.RethinkDBBootstrapStatus{
+		Phase:   rethinkdbv1alpha1.BootstrapPhaseRestoring,
+		Archive: source.Archive,
+		JobName: job.Name,
+		Message: fmt.Sprintf("restoring archive %s", source.Archive),
+	}
+	return reconcile.Result{}, nil
+}
+
+// reconcileBootstrapSnapshots selects the volume snapshots the data volume claims of the given new RethinkDBCluster are
+// provisioned from, when its bootstrap backup is taken with volume snapshots. Returns true while the servers must not
+// be created, as a server started on an empty volume would not be restored.
+func (r *ReconcileRethinkDBCluster) reconcileBootstrapSnapshots(cr *rethinkdbv1alpha1.RethinkDBCluster) (bool, error) {
+	policy := cr.Spec.Bootstrap
+	if policy == nil || policy.Backup == "" || policy.Source != nil {
+		return false, nil
+	}
+	if cr.Status.Bootstrap == nil && !isNewCluster(cr) {
+		return false, nil
+	}
+	if cr.Status.Bootstrap != nil && cr.Status.Bootstrap.Phase != rethinkdbv1alpha1.BootstrapPhaseWaiting {
+		return false, nil
+	}
+
+	backup := &rethinkdbv1alpha1.RethinkDBBackup{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: policy.Backup, Namespace: cr.Namespace}, backup)
+	if err != nil && errors.IsNotFound(err) {
+		// The restore of the archive reports the missing backup.
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if backup.Spec.Storage.VolumeSnapshot == nil {
+		return false, nil
+	}
+
+	if cr.Status.Bootstrap == nil {
+		cr.Status.Bootstrap = &rethinkdbv1alpha1.RethinkDBBootstrapStatus{Phase: rethinkdbv1alpha1.BootstrapPhaseWaiting}
+	}
+	status := cr.Status.Bootstrap
+	if !isPVEnabled(cr) {
+		status.Message = "a persistentVolumeClaimSpec is required to provision the servers from volume snapshots"
+		return true, nil
+	}
+
+	var record *rethinkdbv1alpha1.RethinkDBBackupRecord
+	for i := range backup.Status.Backups {
+		if backup.Status.Backups[i].Phase == rethinkdbv1alpha1.BackupPhaseSucceeded && len(backup.Status.Backups[i].Snapshots) > 0 {
+			record = &backup.Status.Backups[i]
+			break
+		}
+	}
+	if record == nil {
+		status.Message = fmt.Sprintf("RethinkDBBackup %s has no successful volume snapshots", backup.Name)
+		return true, nil
+	}
+	if int32(len(record.Snapshots)) != cr.Spec.Size {
+		status.Message = fmt.Sprintf("RethinkDBBackup %s has %d volume snapshots, the cluster size must be %d",
+			backup.Name, len(record.Snapshots), len(record.Snapshots))
+		return true, nil
+	}
+
+	pvcs, err := r.listPVCs(cr)
+	if err != nil {
+		return false, err
+	}
+	if len(pvcs) > 0 {
+		status.Message = "the data volume claims of a previous cluster with the same name exist and would not be provisioned from the volume snapshots"
+		return true, nil
+	}
+
+	*status = rethinkdbv1alpha1.RethinkDBBootstrapStatus{
+		Phase:     rethinkdbv1alpha1.BootstrapPhaseRestoring,
+		Archive:   record.JobName,
+		Snapshots: append([]string{}, record.Snapshots...),
+		Message:   fmt.Sprintf("provisioning the servers from the volume snapshots of %s", record.JobName),
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonBootstrapStarted, "Provisioning the servers from the volume snapshots of %s", record.JobName)
+	return false, nil
+}
+
+// reconcileBootstrapJob records the progress of the given bootstrap Job for the given RethinkDBCluster.
+func (r *ReconcileRethinkDBCluster) reconcileBootstrapJob(cr *rethinkdbv1alpha1.RethinkDBCluster, job *batchv1.Job) error {
+	status := cr.Status.Bootstrap
+	status.JobName = job.Name
+	status.StartTime = job.Status.StartTime
+
+	succeeded := dump.IsJobSucceeded(job)
+	if !succeeded && !dump.IsJobFailed(job) {
+		status.Phase = rethinkdbv1alpha1.BootstrapPhaseRestoring
+		return nil
+	}
+	if status.Phase == rethinkdbv1alpha1.BootstrapPhaseFailed {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	listOps := &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{"job-name": job.Name}),
+	}
+	if err := r.client.List(context.TODO(), listOps, pods); err != nil {
+		return err
+	}
+	result := dump.ReadResult(pods.Items)
+	if result == nil {
+		result = &dump.Result{}
+	}
+	status.CompletionTime = dump.JobFinishTime(job)
+
+	if succeeded {
+		status.Phase = rethinkdbv1alpha1.BootstrapPhaseCompleted
+		status.Tables = result.Tables
+		status.Message = fmt.Sprintf("restored %d tables from archive %s", len(result.Tables), status.Archive)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonBootstrapCompleted, "Restored %d tables from archive %s", len(result.Tables), status.Archive)
+		return nil
+	}
+
+	status.Phase = rethinkdbv1alpha1.BootstrapPhaseFailed
+	status.Message = result.Error
+	if status.Message == "" {
+		status.Message = fmt.Sprintf("job %s failed", job.Name)
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeWarning, eventReasonBootstrapFailed, "Unable to restore archive %s: %s", status.Archive, status.Message)
+	return nil
+}
+
+// reconcileCAConfigMap ensures the cluster CA ConfigMap with the bundle of trusted CA certificates is present.
+// The bundle is returned upon success to be used by other reconcilers.
+func (r *ReconcileRethinkDBCluster) reconcileCAConfigMap(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret) ([]byte, error) {
+	name := rdbtls.CAConfigMapName(cr)
+	found := &corev1.ConfigMap{}
+
+	bundle, err := r.trustBundle(cr, caSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("creating new configmap", "configmap", name)
+		cm := newCAConfigMap(cr, bundle)
+
+		// Set RethinkDBCluster instance as the owner and controller
+		if err = controllerutil.SetControllerReference(cr, cm, r.scheme); err != nil {
+			return nil, err
+		}
+
+		if err = r.client.Create(context.TODO(), cm); err != nil {
+			return nil, err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created configmap %s", name)
+		return bundle, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = r.revertDrift(cr, found, "configmap", found.Name, repairConfigMap(found, newCAConfigMap(cr, bundle))); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// reconcileCARotation starts and advances the rotation of the cluster CA. A rotation is started when the rotate-ca
+// annotation changes, or when the CA is within the renewal window. Each stage waits until every server Pod has been
+// restarted with the current certificates and trust bundle before moving on to the next stage.
+func (r *ReconcileRethinkDBCluster) reconcileCARotation(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret) error {
+	// An existing CA is rotated by its owner, the servers restart once the new CA is in the trust bundle.
+	if rdbtls.HasExternalCA(cr) || !rdbtls.IssuesCertificates(cr) {
+		return nil
+	}
+
+	if !isCARotating(cr) {
+		reason, err := caRotationReason(cr, caSecret)
+		if err != nil || reason == "" {
+			return err
+		}
+		return r.startCARotation(cr, caSecret, reason)
+	}
+
+	servers, err := r.listServers(cr)
+	if err != nil {
+		return err
+	}
+
+	rotation := cr.Status.CARotation
+	if !serversRestarted(cr, servers) {
+		log.Info("waiting for server pods to restart before continuing ca rotation...", "stage", rotation.Stage)
+		return nil
+	}
+
+	switch rotation.Stage {
+	case rethinkdbv1alpha1.CARotationTrustingNewCA:
+		return r.swapCA(cr, caSecret)
+	case rethinkdbv1alpha1.CARotationReissuing:
+		// Clients still presenting a certificate from the old CA are rejected once the old CA is no longer trusted
+		pending, err := r.pendingClientCertSecrets(cr, caSecret)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			log.Info("waiting for client certificates to be re-issued before continuing ca rotation...", "secrets", pending)
+			message := fmt.Sprintf("waiting for client certificates to be re-issued from the new CA: %s", strings.Join(pending, ", "))
+			if rotation.Message == message {
+				return nil
+			}
+			rotation.Message = message
+			return r.client.Status().Update(context.TODO(), cr)
+		}
+
+		// Every certificate has been re-issued from the new CA, the old CA is no longer needed
+		log.Info("removing old ca from trust bundle", "secret", caSecret.Name)
+		caSecret.Data[TLSCACertKey] = caSecret.Data[corev1.TLSCertKey]
+		if err = r.client.Update(context.TODO(), caSecret); err != nil {
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, eventReasonCARotationProgressing, "Removed the old CA from the trust bundle")
+		rotation.Stage = rethinkdbv1alpha1.CARotationRemovingOldCA
+		rotation.Message = ""
+	case rethinkdbv1alpha1.CARotationRemovingOldCA:
+		log.Info("ca rotation completed")
+		r.recorder.Event(cr, corev1.EventTypeNormal, eventReasonCARotationCompleted, "Completed rotation of the cluster CA")
+		now := metav1.Now()
+		rotation.Stage = rethinkdbv1alpha1.CARotationCompleted
+		rotation.CompletionTime = &now
+	}
+	return r.client.Status().Update(context.TODO(), cr)
+}
+
+// reconcileCASecret ensures the CA TLS Secret is created, or verifies the existing CA Secret from the spec.
+// The secret is returned upon success to be used by other reconcilers. No secret is returned when existing
+// certificates are used without a CA Secret.
+func (r *ReconcileRethinkDBCluster) reconcileCASecret(cr *rethinkdbv1alpha1.RethinkDBCluster) (*corev1.Secret, error) {
+	if rdbtls.HasExternalCA(cr) {
+		return r.reconcileExternalCASecret(cr)
+	}
+	if !rdbtls.IssuesCertificates(cr) {
+		// Certificates without a CA Secret are verified against the CA bundles in their Secrets
+		return nil, nil
+	}
+
+	name := rdbtls.CASecretName(cr)
+	found := &corev1.Secret{}
 
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
@@ -385,15 +1185,102 @@ func (r *ReconcileRethinkDBCluster) reconcileCASecret(cr *rethinkdbv1alpha1.Reth
 			return nil, err
 		}
 
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonIssuedCA, "Issued self-signed CA certificate in secret %s", name)
 		return secret, nil
 	} else if err != nil {
 		return nil, err
 	}
 
-	log.Info("ca secret exists", "secret", found.Name)
+	// Secret exists, re-issue the CA if the certificate or key have been changed or removed
+	reverted := repairLabels(&found.ObjectMeta, &newTLSSecret(cr, name).ObjectMeta)
+	if invalid := rdbtls.ValidateCASecret(found); invalid != nil {
+		log.Info("re-issuing invalid ca secret", "secret", name, "error", invalid.Error())
+		secret, err := newCASecret(cr, name)
+		if err != nil {
+			return nil, err
+		}
+		found.Data = secret.Data
+		reverted = append(reverted, fmt.Sprintf("data (re-issued: %v)", invalid))
+	}
+
+	// Ensure the trust bundle includes the CA
+	if !bundleContains(found.Data[TLSCACertKey], found.Data[corev1.TLSCertKey]) {
+		found.Data[TLSCACertKey] = found.Data[corev1.TLSCertKey]
+		reverted = append(reverted, fmt.Sprintf("data.%s", TLSCACertKey))
+	}
+
+	if err = r.revertDrift(cr, found, "secret", found.Name, reverted); err != nil {
+		return nil, err
+	}
 	return found, nil
 }
 
+// reconcileCertificates ensures the cert-manager Certificates are created for the cluster, driver, http and client
+// TLS Secrets. Returns true once cert-manager reports every Certificate as ready.
+func (r *ReconcileRethinkDBCluster) reconcileCertificates(cr *rethinkdbv1alpha1.RethinkDBCluster) (bool, error) {
+	ready := true
+	for _, suffix := range []string{RethinkDBClusterKey, RethinkDBDriverKey, RethinkDBHttpKey, RethinkDBClientKey} {
+		desired := newCertificate(cr, suffix)
+		found := &unstructured.Unstructured{}
+		found.SetGroupVersionKind(certificateGVK)
+
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: desired.GetName(), Namespace: cr.Namespace}, found)
+		if err != nil && errors.IsNotFound(err) {
+			log.Info("creating new certificate", "certificate", desired.GetName())
+
+			// Set RethinkDBCluster instance as the owner and controller
+			if err = controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+				return false, err
+			}
+
+			if err = r.client.Create(context.TODO(), desired); err != nil {
+				return false, err
+			}
+
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created certificate %s", desired.GetName())
+			ready = false
+			continue
+		} else if err != nil {
+			return false, err
+		}
+
+		if err = r.revertDrift(cr, found, "certificate", found.GetName(), repairCertificate(found, desired)); err != nil {
+			return false, err
+		}
+		ready = ready && isCertificateReady(found)
+	}
+	return ready, nil
+}
+
+// reconcileClusterService ensures the headless cluster Service is present.
+func (r *ReconcileRethinkDBCluster) reconcileClusterService(cr *rethinkdbv1alpha1.RethinkDBCluster) error {
+	found := &corev1.Service{}
+	name := clusterServiceName(cr)
+
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("creating new service", "service", name)
+		svc := newClusterService(cr)
+
+		// Set RethinkDBCluster instance as the owner and controller
+		if err = controllerutil.SetControllerReference(cr, svc, r.scheme); err != nil {
+			return err
+		}
+
+		// Create the Service and return
+		if err = r.client.Create(context.TODO(), svc); err != nil {
+			return err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created service %s", name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return r.revertDrift(cr, found, "service", found.Name, repairService(found, newClusterService(cr)))
+}
+
 // reconcileDriverService ensures the driver Service is present.
 func (r *ReconcileRethinkDBCluster) reconcileDriverService(cr *rethinkdbv1alpha1.RethinkDBCluster) error {
 	found := &corev1.Service{}
@@ -412,6 +1299,7 @@ func (r *ReconcileRethinkDBCluster) reconcileDriverService(cr *rethinkdbv1alpha1
 		if err != nil {
 			return err
 		}
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCreated, "Created service %s", svc.Name)
 
 		// Service created successfully, update status and return
 		cr.Status.ServiceName = svc.Name
@@ -420,11 +1308,78 @@ func (r *ReconcileRethinkDBCluster) reconcileDriverService(cr *rethinkdbv1alpha1
 		return err
 	}
 
-	log.Info("service exists", "service", found.Name)
-	return nil
+	return r.revertDrift(cr, found, "service", found.Name, repairService(found, newDriverService(cr)))
+}
+
+// reconcileExternalCASecret verifies the existing CA Secret from the spec. The Secret is not owned by the cluster
+// and is never changed by the operator. The secret is returned upon success.
+func (r *ReconcileRethinkDBCluster) reconcileExternalCASecret(cr *rethinkdbv1alpha1.RethinkDBCluster) (*corev1.Secret, error) {
+	found := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: rdbtls.CASecretName(cr), Namespace: cr.Namespace}, found)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateExternalCASecret(cr, found); err != nil {
+		return nil, fmt.Errorf("invalid ca secret %s: %v", found.Name, err)
+	}
+	return found, nil
+}
+
+// reconcileExternalTLSSecret fetches the existing or cert-manager issued TLS Secret with the given suffix. The certificate is
+// verified against the given CA bundle and any mismatch is recorded in the given TLS status.
+// The Secret is not owned by the cluster and is never changed by the operator. The secret is returned upon success.
+func (r *ReconcileRethinkDBCluster) reconcileExternalTLSSecret(cr *rethinkdbv1alpha1.RethinkDBCluster, bundle []byte, suffix string, status *rethinkdbv1alpha1.RethinkDBTLSStatus) (*corev1.Secret, error) {
+	found := &corev1.Secret{}
+	name := rdbtls.SecretName(cr, suffix)
+	if name == "" {
+		return nil, fmt.Errorf("no existing secret set for the %s certificate", suffix)
+	}
+
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := rdbtls.ParsePEMEncodedCert(found.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s in secret %s: %v", corev1.TLSCertKey, name, err)
+	}
+
+	certStatus := rethinkdbv1alpha1.RethinkDBCertificateStatus{
+		Secret:   name,
+		NotAfter: metav1.NewTime(cert.NotAfter),
+	}
+	if mismatches := certificateMismatches(cr, suffix, found, bundle); len(mismatches) > 0 {
+		certStatus.Message = strings.Join(mismatches, "; ")
+		log.Info("existing certificate does not match", "secret", name, "mismatches", certStatus.Message)
+
+		// Only record an event when the mismatch is first found
+		if previous := findCertificateStatus(cr.Status.TLS, name); previous == nil || previous.Message != certStatus.Message {
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, eventReasonCertificateMismatch, "Certificate in secret %s does not match the %s certificate: %s",
+				name, suffix, certStatus.Message)
+		}
+	}
+	status.Certificates = append(status.Certificates, certStatus)
+	return found, nil
 }
 
-// reconcilePersistentVolumeClaims ensures the requested number of PVCs are created.
+// reconcileFailed records the failed reconcile step with the given reason in the status of the given RethinkDBCluster.
+// The original error is returned so the request is requeued.
+func (r *ReconcileRethinkDBCluster) reconcileFailed(cr *rethinkdbv1alpha1.RethinkDBCluster, reason string, err error) (reconcile.Result, error) {
+	r.recorder.Event(cr, corev1.EventTypeWarning, reason, err.Error())
+
+	rethinkdbv1alpha1.SetCondition(&cr.Status.Conditions, rethinkdbv1alpha1.ClusterConditionProgressing, corev1.ConditionFalse, reason, err.Error())
+	cr.Status.Phase = rethinkdbv1alpha1.ClusterPhaseFailed
+	cr.Status.ObservedGeneration = cr.ObjectMeta.Generation
+
+	if updateErr := r.client.Status().Update(context.TODO(), cr); updateErr != nil {
+		log.Error(updateErr, "unable to update cluster status")
+	}
+	return reconcile.Result{}, err
+}
+
+// reconcilePersistentVolumeClaims ensures a PVC is created for each requested server ordinal.
 func (r *ReconcileRethinkDBCluster) reconcilePersistentVolumeClaims(cr *rethinkdbv1alpha1.RethinkDBCluster) error {
 	if !isPVEnabled(cr) {
 		return nil
@@ -435,146 +1390,651 @@ func (r *ReconcileRethinkDBCluster) reconcilePersistentVolumeClaims(cr *rethinkd
 		log.Error(err, "unable to list persistent volume claims")
 		return err
 	}
-	pvcCount := int32(len(pvcs))
 
-	if pvcCount < cr.Spec.Size {
-		return r.addPVC(cr)
-	} else if pvcCount > cr.Spec.Size {
-		return r.removePVC(cr, pvcs)
+	existing := map[string]bool{}
+	for _, pvc := range pvcs {
+		existing[pvc.Name] = true
+	}
+
+	for ordinal := int32(0); ordinal < cr.Spec.Size; ordinal++ {
+		if existing[claimNameForOrdinal(cr, ordinal)] {
+			continue
+		}
+		if err = r.addPVC(cr, ordinal); err != nil {
+			return err
+		}
+	}
+
+	if int32(len(pvcs)) > cr.Spec.Size {
+		servers, err := r.listServers(cr)
+		if err != nil {
+			log.Error(err, "unable to list servers")
+			return err
+		}
+		return r.removePVC(cr, pvcs, servers)
 	}
 
-	log.Info("correct persistent volume claim count reached", "count", pvcCount)
+	log.Info("correct persistent volume claim count reached", "count", len(pvcs))
 	return nil
 }
 
 // reconcileServers ensures the requested number of server Pods are created.
-func (r *ReconcileRethinkDBCluster) reconcileServerPods(cr *rethinkdbv1alpha1.RethinkDBCluster) error {
+func (r *ReconcileRethinkDBCluster) reconcileServerPods(cr *rethinkdbv1alpha1.RethinkDBCluster) (reconcile.Result, error) {
 	servers, err := r.listServers(cr)
 	if err != nil {
 		log.Error(err, "unable to list servers")
-		return err
+		return reconcile.Result{}, err
 	}
 	serverCount := int32(len(servers))
 
+	// Ensure no Pods are terminating before making any changes, as the names of server Pods are reused.
+	for _, pod := range servers {
+		if pod.ObjectMeta.DeletionTimestamp != nil {
+			log.Info("waiting for server pods to terminate...")
+			return reconcile.Result{}, nil
+		}
+	}
+
 	if serverCount < cr.Spec.Size {
 		// Ensure all existing Pods are running before adding a new Pod.
 		for _, pod := range servers {
 			if pod.Status.Phase != corev1.PodRunning {
 				log.Info("waiting for existing server pods to become ready...")
-				return nil
+				return reconcile.Result{}, nil
 			}
 		}
-		return r.addServer(cr, servers)
+		return reconcile.Result{}, r.addServer(cr, servers)
 	} else if serverCount > cr.Spec.Size {
+		if cr.Status.Upgrade != nil {
+			log.Info("waiting for upgrade to complete before scaling down...")
+			return r.upgradeServers(cr, servers)
+		}
 		return r.removeServer(cr, servers)
 	}
 
+	// Cancel any drain in progress, the cluster is no longer being scaled down.
+	if cr.Status.Drain != nil {
+		log.Info("cancelling drain of server pod", "pod", cr.Status.Drain.Server)
+		cr.Status.Drain = nil
+		return reconcile.Result{}, r.client.Status().Update(context.TODO(), cr)
+	}
+
 	log.Info("correct cluster size reached", "size", serverCount)
-	return nil
+
+	// Roll out any change to the RethinkDB version, one server at a time.
+	result, err := r.upgradeServers(cr, servers)
+	if err != nil || cr.Status.Upgrade != nil {
+		return result, err
+	}
+
+	// Restart the servers that are not running the current certificates, one server at a time.
+	return r.restartServers(cr, servers)
 }
 
-// reconcileCertificates ensures the TLS secrets are created for the given RethinkDBCluster.
-func (r *ReconcileRethinkDBCluster) reconcileTLSSecrets(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret) error {
+// reconcileStatus updates the phase, conditions, ready server count and running version in the status of the
+// given RethinkDBCluster. The status is only written if it differs from the given observed status.
+func (r *ReconcileRethinkDBCluster) reconcileStatus(cr *rethinkdbv1alpha1.RethinkDBCluster, observed *rethinkdbv1alpha1.RethinkDBClusterStatus) error {
+	servers, err := r.listServers(cr)
+	if err != nil {
+		return err
+	}
+
+	issues := []admin.Issue{}
+	var queryErr error
+	for i := range servers {
+		if !isPodReady(&servers[i]) {
+			continue
+		}
+
+		// At least one server is ready, query the cluster for the running version and any current issues.
+		version, current, err := r.queryClusterStatus(cr)
+		if err != nil {
+			// The cluster may not be accepting connections yet, keep the last known version.
+			log.Info("unable to query cluster status", "error", err.Error())
+			queryErr = err
+		} else {
+			cr.Status.Version = version
+			issues = current
+		}
+		break
+	}
+
+	setClusterConditions(cr, servers, issues, queryErr)
+
+	// Only record the failure to query the cluster when it degrades the cluster, not on every reconcile.
+	degraded := rethinkdbv1alpha1.FindCondition(observed.Conditions, rethinkdbv1alpha1.ClusterConditionDegraded)
+	if queryErr != nil && (degraded == nil || degraded.Reason != reasonStatusUnreachable) {
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, eventReasonStatusUnreachable, "Unable to query cluster status: %v", queryErr)
+	}
+	if apiequality.Semantic.DeepEqual(observed, &cr.Status) {
+		return nil
+	}
+
+	log.Info("updating cluster status", "phase", cr.Status.Phase, "ready", cr.Status.ReadyServers)
+	return r.client.Status().Update(context.TODO(), cr)
+}
+
+// reconcileTLSSecrets ensures the TLS secrets are created for the given RethinkDBCluster, or verifies the existing
+// TLS secrets from the spec or cert-manager against the given CA bundle. The expiry of each certificate and the revision of the
+// certificates mounted in the server Pods are recorded in the status.
+func (r *ReconcileRethinkDBCluster) reconcileTLSSecrets(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret, bundle []byte) error {
+	caPEM := bundle
+	if caSecret != nil {
+		caPEM = caSecret.Data[corev1.TLSCertKey]
+	}
+
+	if !rdbtls.IssuesCertificates(cr) {
+		status, err := newTLSStatus(caPEM)
+		if err != nil {
+			return err
+		}
+
+		secrets := []*corev1.Secret{}
+		for _, suffix := range []string{RethinkDBClusterKey, RethinkDBDriverKey, RethinkDBHttpKey, RethinkDBClientKey} {
+			secret, err := r.reconcileExternalTLSSecret(cr, bundle, suffix, status)
+			if err != nil {
+				return err
+			}
+			secrets = append(secrets, secret)
+		}
+
+		// The client certificate is not mounted in the server Pods, so it does not require the servers to restart.
+		status.Revision = tlsRevision(cr, bundle, secrets[:3]...)
+		cr.Status.TLS = status
+		return nil
+	}
+
 	// Reconcile the cluster certificate Secret
-	err := r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBClusterKey)
+	clusterSecret, err := r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBClusterKey)
 	if err != nil {
 		return err
 	}
 
 	// Reconcile the driver certificate Secret
-	err = r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBDriverKey)
+	driverSecret, err := r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBDriverKey)
 	if err != nil {
 		return err
 	}
 
 	// Reconcile the http (web-admin) certificate Secret
-	err = r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBHttpKey)
+	httpSecret, err := r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBHttpKey)
 	if err != nil {
 		return err
 	}
 
 	// Reconcile the client certificate Secret
-	err = r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBClientKey)
-	return err
+	clientSecret, err := r.reconcileTLSSecretWithSuffix(cr, caSecret, RethinkDBClientKey)
+	if err != nil {
+		return err
+	}
+
+	status, err := newTLSStatus(caPEM, clusterSecret, driverSecret, httpSecret, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	// The client certificate is not mounted in the server Pods, so it does not require the servers to restart.
+	status.Revision = tlsRevision(cr, bundle, clusterSecret, driverSecret, httpSecret)
+	cr.Status.TLS = status
+	return nil
 }
 
 // reconcileTLSSecretWithSuffix ensures the TLS Secret is created for the given Service with the given suffix.
-func (r *ReconcileRethinkDBCluster) reconcileTLSSecretWithSuffix(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret, suffix string) error {
+// The certificate is renewed when it is within the renewal window, and re-issued when the key policy or names change. The Secret is returned upon success.
+func (r *ReconcileRethinkDBCluster) reconcileTLSSecretWithSuffix(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret, suffix string) (*corev1.Secret, error) {
 	found := &corev1.Secret{}
-	name := fmt.Sprintf("%s-%s", cr.Name, suffix)
+	name := rdbtls.SecretName(cr, suffix)
 
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
+	caCert, err := rdbtls.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+
+	caKey, err := rdbtls.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
 		log.Info("creating new secret", "secret", name)
 
-		caCert, err := parsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+		secret, err := newCertificateSecret(cr, name, suffix, caCert, caKey)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		caKey, err := parsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+		// Set RethinkDB instance as the owner and controller
+		if err = controllerutil.SetControllerReference(cr, secret, r.scheme); err != nil {
+			return nil, err
+		}
+
+		if err = r.client.Create(context.TODO(), secret); err != nil {
+			return nil, err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonIssuedCert, "Issued %s certificate in secret %s", suffix, name)
+		return secret, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Secret exists, re-issue the certificate if it has been changed, removed or is not signed by the CA
+	reverted := repairLabels(&found.ObjectMeta, &newTLSSecret(cr, name).ObjectMeta)
+	if invalid := rdbtls.ValidateCertificateSecret(found, caCert); invalid != nil {
+		log.Info("re-issuing invalid certificate secret", "secret", name, "error", invalid.Error())
+		secret, err := newCertificateSecret(cr, name, suffix, caCert, caKey)
+		if err != nil {
+			return nil, err
+		}
+		found.Data = secret.Data
+
+		// Certificates are expected to be re-issued once the new CA is signing during a CA rotation.
+		if isCARotating(cr) {
+			if err = r.client.Update(context.TODO(), found); err != nil {
+				return nil, err
+			}
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonReissuedCert, "Re-issued %s certificate in secret %s from the new CA", suffix, name)
+			return found, nil
+		}
+		reverted = append(reverted, fmt.Sprintf("data (re-issued: %v)", invalid))
+	}
+	if err = r.revertDrift(cr, found, "secret", found.Name, reverted); err != nil {
+		return nil, err
+	}
+
+	// Renew the certificate if it expires within the renewal window, or re-issue it if the key policy or the names
+	// the certificate is reached by have changed
+	cert, err := rdbtls.ParsePEMEncodedCert(found.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+	renew := needsRenewal(cert, rdbtls.RenewalWindow(rdbtls.RenewBefore(cr), rdbtls.CertificateDuration(cr)))
+	reissue := !rdbtls.KeyMatchesPolicy(cr, cert) || !dnsNamesMatch(cert, certificateDNSNames(cr, suffix))
+	if !renew && !reissue {
+		return found, nil
+	}
+
+	log.Info("renewing certificate secret", "secret", name, "notAfter", cert.NotAfter, "policyChanged", reissue)
+	secret, err := newCertificateSecret(cr, name, suffix, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+	found.Data = secret.Data
+	if err = r.client.Update(context.TODO(), found); err != nil {
+		return nil, err
+	}
+
+	if reissue {
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonReissuedCert, "Re-issued %s certificate in secret %s to match the TLS policy",
+			suffix, name)
+		return found, nil
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonRenewedCert, "Renewed %s certificate in secret %s, the previous certificate expires at %s",
+		suffix, name, cert.NotAfter.UTC().Format(time.RFC3339))
+	return found, nil
+}
+
+// removePVC will delete surplus PVCs from the cluster. Only claims for ordinals beyond the requested cluster size,
+// that are not in use by a server Pod, will be deleted.
+func (r *ReconcileRethinkDBCluster) removePVC(cr *rethinkdbv1alpha1.RethinkDBCluster, pvcs []corev1.PersistentVolumeClaim, servers []corev1.Pod) error {
+	used := usedClaims(servers)
+	for _, pvc := range pvcs {
+		ordinal, ok := claimOrdinal(cr, pvc.Name)
+		if !ok || ordinal < cr.Spec.Size || used[pvc.Name] {
+			continue
+		}
+
+		log.Info("removing existing persistent volume claim", "pvc", pvc.ObjectMeta.Name)
+		err := r.client.Delete(context.TODO(), &pvc)
 		if err != nil {
 			return err
 		}
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonDeleted, "Deleted persistent volume claim %s", pvc.Name)
+	}
+	return nil
+}
+
+// removeServer will drain and then delete a server Pod from the cluster. The server with the highest ordinal is removed.
+// Every table is reconfigured away from the server before the Pod is deleted. The progress of the drain is recorded
+// in the status, so that an interrupted drain resumes with the same server.
+// The persistent volume claim for the Pod is deleted as well, as the server is being removed on purpose.
+func (r *ReconcileRethinkDBCluster) removeServer(cr *rethinkdbv1alpha1.RethinkDBCluster, servers []corev1.Pod) (reconcile.Result, error) {
+	target := drainTarget(cr, servers)
+	if target == nil {
+		return reconcile.Result{}, nil
+	}
+	pod := *target
+
+	if cr.Status.Drain == nil || cr.Status.Drain.Server != pod.Name {
+		log.Info("starting drain of server pod", "pod", pod.Name)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonScalingDown, "Scaling down from %d to %d servers", len(servers), cr.Spec.Size)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonDrainStarted, "Moving table replicas away from server %s", pod.Name)
+		now := metav1.Now()
+		cr.Status.Drain = &rethinkdbv1alpha1.RethinkDBDrainStatus{
+			Server:    pod.Name,
+			Phase:     rethinkdbv1alpha1.DrainPhaseReconfiguring,
+			StartTime: &now,
+		}
+		if err := r.client.Status().Update(context.TODO(), cr); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if cr.Status.Drain.Phase != rethinkdbv1alpha1.DrainPhaseRemoving {
+		drained, err := r.drainServer(cr, pod, servers)
+		if err != nil {
+			return reconcile.Result{}, err
+		} else if !drained {
+			return reconcile.Result{RequeueAfter: drainRequeueDelay}, nil
+		}
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonDrainCompleted, "Server %s no longer holds any table replicas", pod.Name)
+	}
+
+	log.Info("removing existing server pod", "pod", pod.Name)
+	err := r.client.Delete(context.TODO(), &pod)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	} else if err == nil {
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonDeleted, "Deleted server pod %s", pod.Name)
+	}
+
+	if claimName := claimNameForPod(&pod); claimName != "" {
+		log.Info("removing existing persistent volume claim", "pvc", claimName)
+		pvc := &corev1.PersistentVolumeClaim{}
+		err = r.client.Get(context.TODO(), types.NamespacedName{Name: claimName, Namespace: cr.Namespace}, pvc)
+		if err != nil && !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		} else if err == nil {
+			if err = r.client.Delete(context.TODO(), pvc); err != nil {
+				return reconcile.Result{}, err
+			}
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonDeleted, "Deleted persistent volume claim %s", claimName)
+		}
+	}
 
-		secret, err := newCertificateSecret(cr, name, caCert, caKey)
+	// Pod deleted successfully, update status and return
+	cr.Status.Drain = nil
+	cr.Status.Servers = []string{}
+	for _, server := range servers {
+		if server.Name != pod.Name {
+			cr.Status.Servers = append(cr.Status.Servers, server.Name)
+		}
+	}
+	return reconcile.Result{}, r.client.Status().Update(context.TODO(), cr)
+}
+
+// restartServers restarts the server Pods that are not running the current certificates, one at a time, so that
+// RethinkDB loads the renewed certificates. A server Pod is only restarted when all servers are ready and every table
+// reports all replicas ready. The deleted Pod is re-created with the same name and volume by reconcileServerPods.
+func (r *ReconcileRethinkDBCluster) restartServers(cr *rethinkdbv1alpha1.RethinkDBCluster, servers []corev1.Pod) (reconcile.Result, error) {
+	target := restartTarget(cr, servers)
+	if target == nil {
+		return reconcile.Result{}, nil
+	}
+
+	for i := range servers {
+		if !isPodReady(&servers[i]) {
+			log.Info("waiting for server pods to become ready before restarting...")
+			return reconcile.Result{RequeueAfter: restartRequeueDelay}, nil
+		}
+	}
+
+	ac, err := r.newAdminClient(r.client, cr)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer ac.Close()
+
+	ready, err := allReplicasReady(ac)
+	if err != nil || !ready {
+		return reconcile.Result{RequeueAfter: restartRequeueDelay}, err
+	}
+
+	log.Info("restarting server pod", "pod", target.Name)
+	err = r.client.Delete(context.TODO(), target)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonRestartingServer, "Restarting server %s to load renewed certificates", target.Name)
+	return reconcile.Result{RequeueAfter: restartRequeueDelay}, nil
+}
+
+// revertDrift updates the given object owned by the RethinkDBCluster when any of its managed fields were reverted to
+// the desired state, and reports the revert as an event.
+func (r *ReconcileRethinkDBCluster) revertDrift(cr *rethinkdbv1alpha1.RethinkDBCluster, obj runtime.Object, kind string, name string, reverted []string) error {
+	if len(reverted) <= 0 {
+		log.Info(fmt.Sprintf("%s exists", kind), kind, name)
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("reverting out-of-band changes to %s", kind), kind, name, "fields", reverted)
+	if err := r.client.Update(context.TODO(), obj); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(cr, corev1.EventTypeWarning, eventReasonDriftReverted, "Reverted out-of-band changes to %s %s: %s",
+		kind, name, strings.Join(reverted, ", "))
+	return nil
+}
+
+// startAdminPasswordRotation generates a new admin password for the given RethinkDBCluster and stores it in the admin
+// rotation Secret, before it is applied to the cluster.
+func (r *ReconcileRethinkDBCluster) startAdminPasswordRotation(cr *rethinkdbv1alpha1.RethinkDBCluster, rotationSecret *corev1.Secret, reason string) error {
+	psswd, err := generatePassword()
+	if err != nil {
+		return err
+	}
+
+	log.Info("generating new admin password", "reason", reason)
+	if rotationSecret.Data == nil {
+		rotationSecret.Data = map[string][]byte{}
+	}
+	rotationSecret.Data[adminNextPasswordKey] = []byte(psswd)
+	if err = r.client.Update(context.TODO(), rotationSecret); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonAdminPasswordRotationStarted, "Started rotation of the admin password (%s)", reason)
+	now := metav1.Now()
+	cr.Status.AdminPasswordRotation = &rethinkdbv1alpha1.RethinkDBAdminPasswordRotationStatus{
+		Stage:     rethinkdbv1alpha1.AdminPasswordRotationApplying,
+		Reason:    reason,
+		Trigger:   cr.ObjectMeta.Annotations[RethinkDBRotateAdminPasswordAnnotation],
+		StartTime: &now,
+	}
+	return r.client.Status().Update(context.TODO(), cr)
+}
+
+// startCARotation starts the rotation of the cluster CA for the given reason. A new CA is issued in a separate Secret
+// and added to the trust bundle, so the servers trust both the old and new CA once they have been restarted.
+func (r *ReconcileRethinkDBCluster) startCARotation(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret, reason string) error {
+	name := nextCASecretName(cr)
+	next := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, next)
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("creating new ca secret", "secret", name)
+		next, err = newCASecret(cr, name)
 		if err != nil {
 			return err
 		}
 
 		// Set RethinkDB instance as the owner and controller
-		if err = controllerutil.SetControllerReference(cr, secret, r.scheme); err != nil {
+		if err = controllerutil.SetControllerReference(cr, next, r.scheme); err != nil {
 			return err
 		}
 
-		return r.client.Create(context.TODO(), secret)
+		if err = r.client.Create(context.TODO(), next); err != nil {
+			return err
+		}
 	} else if err != nil {
 		return err
 	}
 
-	log.Info("secret exists", "secret", found.Name)
-	return nil
+	log.Info("adding new ca to trust bundle", "secret", caSecret.Name)
+	caSecret.Data[TLSCACertKey] = appendCertificates(caSecret.Data[corev1.TLSCertKey], next.Data[corev1.TLSCertKey])
+	if err = r.client.Update(context.TODO(), caSecret); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonCARotationStarted, "Started rotation of the cluster CA (%s), the new CA is trusted alongside the old CA", reason)
+	now := metav1.Now()
+	cr.Status.CARotation = &rethinkdbv1alpha1.RethinkDBCARotationStatus{
+		Stage:     rethinkdbv1alpha1.CARotationTrustingNewCA,
+		Reason:    reason,
+		Trigger:   cr.ObjectMeta.Annotations[RethinkDBRotateCAAnnotation],
+		StartTime: &now,
+	}
+	return r.client.Status().Update(context.TODO(), cr)
 }
 
-// removePVC will delete a PVC from the cluster. The first unbound PVC in the provided slice of PVCs will be deleted.
-func (r *ReconcileRethinkDBCluster) removePVC(cr *rethinkdbv1alpha1.RethinkDBCluster, pvcs []corev1.PersistentVolumeClaim) error {
-	if len(pvcs) <= 0 {
-		return nil
+// swapCA makes the new CA the signing CA for the cluster, while keeping the old CA in the trust bundle.
+// The certificates signed by the old CA are re-issued from the new CA by reconcileTLSSecrets.
+func (r *ReconcileRethinkDBCluster) swapCA(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret) error {
+	next := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: nextCASecretName(cr), Namespace: cr.Namespace}, next)
+	if err != nil {
+		return err
 	}
 
-	for _, pvc := range pvcs {
-		if pvc.Status.Phase == corev1.ClaimPending {
-			log.Info("removing existing persistent volume claim", "pvc", pvc.ObjectMeta.Name)
-			err := r.client.Delete(context.TODO(), &pvc)
-			if err != nil {
-				return err
-			}
+	log.Info("signing certificates with new ca", "secret", caSecret.Name)
+	caSecret.Data = map[string][]byte{
+		corev1.TLSCertKey:       next.Data[corev1.TLSCertKey],
+		corev1.TLSPrivateKeyKey: next.Data[corev1.TLSPrivateKeyKey],
+		TLSCACertKey:            appendCertificates(next.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSCertKey]),
+	}
+	if err = r.client.Update(context.TODO(), caSecret); err != nil {
+		return err
+	}
+
+	if err = r.client.Delete(context.TODO(), next); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	r.recorder.Event(cr, corev1.EventTypeNormal, eventReasonCARotationProgressing, "Re-issuing certificates from the new CA")
+	cr.Status.CARotation.Stage = rethinkdbv1alpha1.CARotationReissuing
+	return r.client.Status().Update(context.TODO(), cr)
+}
+
+// trustBundle returns the PEM encoded bundle of trusted CA certificates for the given RethinkDBCluster.
+// The bundle is taken from the given CA Secret, or from the existing TLS Secrets when there is no CA Secret.
+func (r *ReconcileRethinkDBCluster) trustBundle(cr *rethinkdbv1alpha1.RethinkDBCluster, caSecret *corev1.Secret) ([]byte, error) {
+	if caSecret != nil {
+		return caTrustBundle(caSecret), nil
+	}
+
+	bundle := []byte{}
+	for _, suffix := range []string{RethinkDBClusterKey, RethinkDBDriverKey, RethinkDBHttpKey, RethinkDBClientKey} {
+		secret := &corev1.Secret{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: rdbtls.SecretName(cr, suffix), Namespace: cr.Namespace}, secret)
+		if err != nil {
+			return nil, err
 		}
+		bundle = mergeCertificates(bundle, secret.Data[TLSCACertKey])
 	}
 
-	// Nothing to remove...
-	return nil
+	if len(bundle) <= 0 {
+		return nil, fmt.Errorf("no CA certificates found, set a CA secret or add %s to the existing TLS secrets", TLSCACertKey)
+	}
+	return bundle, nil
 }
 
-// removeServer will delete a server Pod from the cluster. The first Pod in the provided slice of servers will be deleted.
-func (r *ReconcileRethinkDBCluster) removeServer(cr *rethinkdbv1alpha1.RethinkDBCluster, servers []corev1.Pod) error {
-	if len(servers) <= 0 {
-		return nil
+// upgradeServers performs a rolling upgrade of the server Pods to the requested version. One server at a time has
+// its image replaced in place, keeping the Pod and its data volume. The next server is only upgraded once the
+// current server has rejoined the cluster and every table reports all replicas ready. The upgrade is paused if the
+// server fails to start or does not rejoin within the timeout.
+func (r *ReconcileRethinkDBCluster) upgradeServers(cr *rethinkdbv1alpha1.RethinkDBCluster, servers []corev1.Pod) (reconcile.Result, error) {
+	if cr.Status.Upgrade != nil && cr.Status.Upgrade.TargetVersion != cr.Spec.Version {
+		log.Info("upgrade target version changed", "from", cr.Status.Upgrade.TargetVersion, "to", cr.Spec.Version)
+		cr.Status.Upgrade = nil
 	}
 
-	pod := servers[0]
-	log.Info("removing existing server pod", "pod", pod.Name)
+	target := upgradeTarget(cr, servers)
+	if target == nil {
+		if cr.Status.Upgrade == nil {
+			return reconcile.Result{}, nil
+		}
 
-	err := r.client.Delete(context.TODO(), &pod)
-	if err != nil {
-		return err
+		log.Info("upgrade completed", "version", cr.Spec.Version)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonUpgradeCompleted, "Upgraded all servers to version %s", cr.Spec.Version)
+		cr.Status.Upgrade = nil
+		return reconcile.Result{}, r.client.Status().Update(context.TODO(), cr)
 	}
+	pod := target.DeepCopy()
+
+	now := metav1.Now()
+	if cr.Status.Upgrade == nil {
+		log.Info("starting upgrade", "version", cr.Spec.Version)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonUpgradeStarted, "Upgrading servers to version %s", cr.Spec.Version)
+		cr.Status.Upgrade = &rethinkdbv1alpha1.RethinkDBUpgradeStatus{
+			TargetVersion: cr.Spec.Version,
+			StartTime:     &now,
+		}
+	}
+	upgrade := cr.Status.Upgrade
+
+	if upgrade.Server != pod.Name {
+		// Ensure all servers are ready before the next server is restarted.
+		for i := range servers {
+			if !isPodReady(&servers[i]) {
+				log.Info("waiting for server pods to become ready before upgrading...")
+				return reconcile.Result{RequeueAfter: upgradeRequeueDelay}, nil
+			}
+		}
 
-	// Pod deleted successfully, update status and return
-	servers = append(servers[:0], servers[1:]...)
-	cr.Status.Servers = []string{}
-	for _, pod := range servers {
-		cr.Status.Servers = append(cr.Status.Servers, pod.Name)
+		// Record the server before changing the Pod, so an interrupted upgrade resumes with the same server.
+		upgrade.Server = pod.Name
+		upgrade.ServerStartTime = &now
+		upgrade.Paused = false
+		upgrade.Message = ""
+		if err := r.client.Status().Update(context.TODO(), cr); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
-	return r.client.Status().Update(context.TODO(), cr)
+
+	if isServerOutdated(cr, pod) {
+		log.Info("upgrading server pod", "pod", pod.Name, "image", serverImage(cr))
+		setServerImage(cr, pod)
+		if err := r.client.Update(context.TODO(), pod); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonUpgradingServer, "Upgrading server %s to version %s", pod.Name, cr.Spec.Version)
+		return reconcile.Result{RequeueAfter: upgradeRequeueDelay}, nil
+	}
+
+	if reason, message, failing := containerFailure(pod); failing {
+		return r.pauseUpgrade(cr, fmt.Sprintf("server %s failed to start (%s): %s", pod.Name, reason, message))
+	}
+
+	rejoined := false
+	if isServerRestarted(cr, pod) {
+		ac, err := r.newAdminClient(r.client, cr)
+		if err == nil {
+			rejoined, err = isServerRejoined(ac, serverName(pod.Name))
+			ac.Close()
+		}
+		if err != nil {
+			log.Info("unable to verify upgraded server", "pod", pod.Name, "error", err.Error())
+		}
+	}
+
+	if !rejoined {
+		if upgrade.ServerStartTime != nil && time.Since(upgrade.ServerStartTime.Time) > upgradeServerTimeout {
+			return r.pauseUpgrade(cr, fmt.Sprintf("server %s did not rejoin the cluster within %s", pod.Name, upgradeServerTimeout))
+		}
+		log.Info("waiting for upgraded server to rejoin the cluster...", "pod", pod.Name)
+		return reconcile.Result{RequeueAfter: upgradeRequeueDelay}, nil
+	}
+
+	// Server upgraded successfully, update status and continue with the next server
+	log.Info("server pod upgraded", "pod", pod.Name)
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, eventReasonServerUpgraded, "Upgraded server %s to version %s", pod.Name, cr.Spec.Version)
+	upgrade.UpdatedServers = append(upgrade.UpdatedServers, pod.Name)
+	upgrade.Server = ""
+	upgrade.ServerStartTime = nil
+	upgrade.Paused = false
+	upgrade.Message = ""
+	return reconcile.Result{Requeue: true}, r.client.Status().Update(context.TODO(), cr)
 }
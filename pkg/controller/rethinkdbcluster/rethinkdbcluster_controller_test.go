@@ -0,0 +1,255 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis"
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	// testOldVersion is the RethinkDB version the test servers run before an upgrade.
+	testOldVersion = "2.3.6"
+
+	// testVersion is the RethinkDB version requested by the test cluster.
+	testVersion = "2.4.1"
+)
+
+// newTestCluster returns a RethinkDBCluster with the given size for testing.
+func newTestCluster(size int32) *v1alpha1.RethinkDBCluster {
+	return &v1alpha1.RethinkDBCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.RethinkDBClusterSpec{
+			Size:    size,
+			Version: testVersion,
+		},
+	}
+}
+
+// newTestReconciler returns a reconciler with a fake client holding the given objects, which opens the given fake
+// admin client.
+func newTestReconciler(t *testing.T, ac *fake.Client, objs ...runtime.Object) *ReconcileRethinkDBCluster {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add the API types to the scheme: %v", err)
+	}
+	return &ReconcileRethinkDBCluster{
+		client:         fakeclient.NewFakeClient(objs...),
+		scheme:         scheme.Scheme,
+		recorder:       record.NewFakeRecorder(100),
+		newAdminClient: fake.NewClientFunc(ac),
+	}
+}
+
+// newTestServer returns a ready server Pod with the given ordinal of the given RethinkDBCluster, running the given
+// RethinkDB version.
+func newTestServer(cr *v1alpha1.RethinkDBCluster, ordinal int32, version string) *corev1.Pod {
+	image := fmt.Sprintf("%s:%s", RethinkDBImage, version)
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serverPodName(cr, ordinal),
+			Namespace: cr.Namespace,
+			Labels:    labelsForCluster(cr),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: RethinkDBApp, Image: image}},
+		},
+		Status: corev1.PodStatus{
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: RethinkDBApp, Image: image, Ready: true}},
+		},
+	}
+}
+
+// newTestTableConfig returns the configuration of the table with the given name in the app database, with a single
+// shard replicated to the given servers.
+func newTestTableConfig(name string, servers ...string) admin.TableConfig {
+	return admin.TableConfig{
+		ID:     "app." + name,
+		DB:     "app",
+		Name:   name,
+		Shards: []admin.Shard{{PrimaryReplica: servers[0], Replicas: servers}},
+	}
+}
+
+// newTestTableStatus returns the status of the table with the given name in the app database, with all replicas
+// ready on the given servers.
+func newTestTableStatus(name string, servers ...string) admin.TableStatus {
+	replicas := []admin.ReplicaStatus{}
+	for _, server := range servers {
+		replicas = append(replicas, admin.ReplicaStatus{Server: server, State: "ready"})
+	}
+	return admin.TableStatus{
+		ID:     "app." + name,
+		DB:     "app",
+		Name:   name,
+		Shards: []admin.ShardStatus{{Replicas: replicas}},
+		Status: admin.TableReadiness{AllReplicasReady: true, ReadyForOutdatedReads: true, ReadyForReads: true, ReadyForWrites: true},
+	}
+}
+
+// serverImageOf returns the image of the RethinkDB container of the server Pod with the given name.
+func serverImageOf(t *testing.T, r *ReconcileRethinkDBCluster, cr *v1alpha1.RethinkDBCluster, name string) string {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, pod); err != nil {
+		t.Fatalf("unable to get pod %s: %v", name, err)
+	}
+	return pod.Spec.Containers[0].Image
+}
+
+func TestRemoveServer(t *testing.T) {
+	cr := newTestCluster(2)
+	objs := []runtime.Object{cr}
+	for ordinal := int32(0); ordinal < 4; ordinal++ {
+		objs = append(objs, newTestServer(cr, ordinal, testVersion))
+	}
+	ac := &fake.Client{
+		Tables: []admin.TableConfig{
+			newTestTableConfig("logs", "example_0", "example_1"),
+			newTestTableConfig("users", "example_3"),
+		},
+		TableStatuses: []admin.TableStatus{
+			newTestTableStatus("logs", "example_0", "example_1"),
+			newTestTableStatus("users", "example_3"),
+		},
+	}
+	r := newTestReconciler(t, ac, objs...)
+
+	servers, err := r.listServers(cr)
+	if err != nil {
+		t.Fatalf("unable to list servers: %v", err)
+	}
+
+	// The server with the highest ordinal is drained first. Its replicas only move to the servers that are kept, even
+	// though the server with ordinal 2 holds fewer replicas.
+	result, err := r.removeServer(cr, servers)
+	if err != nil {
+		t.Fatalf("removeServer() error = %v", err)
+	}
+	if result.RequeueAfter != drainRequeueDelay {
+		t.Errorf("removeServer() requeue after = %v, want %v", result.RequeueAfter, drainRequeueDelay)
+	}
+	if drain := cr.Status.Drain; drain == nil || drain.Server != "example-3" || drain.Phase != v1alpha1.DrainPhaseWaiting {
+		t.Errorf("drain status = %+v, want server example-3 in phase %s", drain, v1alpha1.DrainPhaseWaiting)
+	}
+	shard := ac.Tables[1].Shards[0]
+	if want := []string{"example_0"}; !reflect.DeepEqual(shard.Replicas, want) {
+		t.Errorf("replicas = %v, want %v", shard.Replicas, want)
+	}
+	if shard.PrimaryReplica != "example_0" {
+		t.Errorf("primary replica = %s, want example_0", shard.PrimaryReplica)
+	}
+
+	// The Pod is kept until the table no longer reports a replica on the server.
+	pod := &corev1.Pod{}
+	if err = r.client.Get(context.TODO(), types.NamespacedName{Name: "example-3", Namespace: cr.Namespace}, pod); err != nil {
+		t.Fatalf("server pod example-3 was removed before the drain completed: %v", err)
+	}
+
+	ac.TableStatuses[1] = newTestTableStatus("users", "example_0")
+	if _, err = r.removeServer(cr, servers); err != nil {
+		t.Fatalf("removeServer() error = %v", err)
+	}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: "example-3", Namespace: cr.Namespace}, pod)
+	if !errors.IsNotFound(err) {
+		t.Errorf("server pod example-3 was not removed after the drain completed: %v", err)
+	}
+	if cr.Status.Drain != nil {
+		t.Errorf("drain status = %+v, want nil", cr.Status.Drain)
+	}
+	sort.Strings(cr.Status.Servers)
+	if want := []string{"example-0", "example-1", "example-2"}; !reflect.DeepEqual(cr.Status.Servers, want) {
+		t.Errorf("servers = %v, want %v", cr.Status.Servers, want)
+	}
+}
+
+func TestUpgradeServers(t *testing.T) {
+	cr := newTestCluster(2)
+	ac := &fake.Client{
+		Servers:       []admin.ServerStatus{{Name: "example_0"}, {Name: "example_1"}},
+		TableStatuses: []admin.TableStatus{newTestTableStatus("users", "example_0", "example_1")},
+	}
+	r := newTestReconciler(t, ac, cr, newTestServer(cr, 0, testOldVersion), newTestServer(cr, 1, testOldVersion))
+	oldImage := fmt.Sprintf("%s:%s", RethinkDBImage, testOldVersion)
+
+	upgrade := func() {
+		servers, err := r.listServers(cr)
+		if err != nil {
+			t.Fatalf("unable to list servers: %v", err)
+		}
+		if _, err = r.upgradeServers(cr, servers); err != nil {
+			t.Fatalf("upgradeServers() error = %v", err)
+		}
+	}
+
+	// The server with the highest ordinal is upgraded first.
+	upgrade()
+	if status := cr.Status.Upgrade; status == nil || status.Server != "example-1" || status.TargetVersion != testVersion {
+		t.Fatalf("upgrade status = %+v, want server example-1 upgrading to %s", status, testVersion)
+	}
+	if image := serverImageOf(t, r, cr, "example-1"); image != serverImage(cr) {
+		t.Errorf("example-1 image = %s, want %s", image, serverImage(cr))
+	}
+
+	// The next server is not upgraded before the upgraded server restarts with the new image.
+	upgrade()
+	if cr.Status.Upgrade.Server != "example-1" {
+		t.Errorf("upgrading server = %s, want example-1", cr.Status.Upgrade.Server)
+	}
+	if image := serverImageOf(t, r, cr, "example-0"); image != oldImage {
+		t.Errorf("example-0 image = %s, want %s", image, oldImage)
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: "example-1", Namespace: cr.Namespace}, pod); err != nil {
+		t.Fatalf("unable to get pod example-1: %v", err)
+	}
+	pod.Status.ContainerStatuses[0].Image = serverImage(cr)
+	if err := r.client.Update(context.TODO(), pod); err != nil {
+		t.Fatalf("unable to update pod example-1: %v", err)
+	}
+
+	// The server rejoined with all replicas ready, so it is recorded and the next server is upgraded.
+	upgrade()
+	if want := []string{"example-1"}; !reflect.DeepEqual(cr.Status.Upgrade.UpdatedServers, want) {
+		t.Errorf("updated servers = %v, want %v", cr.Status.Upgrade.UpdatedServers, want)
+	}
+	upgrade()
+	if cr.Status.Upgrade.Server != "example-0" {
+		t.Errorf("upgrading server = %s, want example-0", cr.Status.Upgrade.Server)
+	}
+	if image := serverImageOf(t, r, cr, "example-0"); image != serverImage(cr) {
+		t.Errorf("example-0 image = %s, want %s", image, serverImage(cr))
+	}
+}
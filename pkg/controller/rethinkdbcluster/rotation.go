@@ -0,0 +1,112 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"fmt"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// adminCurrentPasswordKey is the key for the password applied to the admin user in the admin rotation Secret.
+	adminCurrentPasswordKey = "current-password"
+
+	// adminNextPasswordKey is the key for the new password being applied to the admin user in the admin rotation
+	// Secret. The key is only present while a rotation is in progress.
+	adminNextPasswordKey = "next-password"
+
+	// adminPasswordRotationReasonRequested is the reason for an admin password rotation started with the
+	// rotate-admin-password annotation.
+	adminPasswordRotationReasonRequested = "Requested"
+
+	// adminPasswordRotationReasonSecretUpdated is the reason for an admin password rotation started because the admin
+	// Secret was updated.
+	adminPasswordRotationReasonSecretUpdated = "SecretUpdated"
+
+	// caRotationReasonExpiring is the reason for a CA rotation started because the CA is within the renewal window.
+	caRotationReasonExpiring = "Expiring"
+
+	// caRotationReasonKeyPolicy is the reason for a CA rotation started because the key algorithm or size changed.
+	caRotationReasonKeyPolicy = "KeyPolicyChanged"
+
+	// caRotationReasonRequested is the reason for a CA rotation started with the rotate-ca annotation.
+	caRotationReasonRequested = "Requested"
+)
+
+// adminPasswordRotationReason returns the reason to start a rotation of the admin password for the given
+// RethinkDBCluster, or an empty string if the password does not need to be rotated. A password in the admin Secret
+// that was not applied by the operator means the Secret was updated, as the cluster only reads it on first boot.
+func adminPasswordRotationReason(cr *v1alpha1.RethinkDBCluster, adminSecret *corev1.Secret, rotationSecret *corev1.Secret) string {
+	trigger := cr.ObjectMeta.Annotations[RethinkDBRotateAdminPasswordAnnotation]
+	if trigger != "" && (cr.Status.AdminPasswordRotation == nil || cr.Status.AdminPasswordRotation.Trigger != trigger) {
+		return adminPasswordRotationReasonRequested
+	}
+
+	// The next password is still present when the admin Secret was read after the last rotation stored the password
+	// there, but the rotation Secret was read before the rotation completed.
+	psswd := string(adminSecret.Data[RethinkDBPasswordKey])
+	if psswd != string(rotationSecret.Data[adminCurrentPasswordKey]) && psswd != string(rotationSecret.Data[adminNextPasswordKey]) {
+		return adminPasswordRotationReasonSecretUpdated
+	}
+	return ""
+}
+
+// adminRotationSecretName returns the name of the Secret holding the admin password applied to the cluster and the
+// new password during a rotation.
+func adminRotationSecretName(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s-%s-rotation", cr.ObjectMeta.Name, RethinkDBAdminKey)
+}
+
+// caRotationReason returns the reason to start a rotation of the CA in the given Secret, or an empty string if the
+// CA does not need to be rotated.
+func caRotationReason(cr *v1alpha1.RethinkDBCluster, caSecret *corev1.Secret) (string, error) {
+	trigger := cr.ObjectMeta.Annotations[RethinkDBRotateCAAnnotation]
+	if trigger != "" && (cr.Status.CARotation == nil || cr.Status.CARotation.Trigger != trigger) {
+		return caRotationReasonRequested, nil
+	}
+
+	cert, err := rdbtls.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return "", err
+	}
+	if needsRenewal(cert, rdbtls.RenewalWindow(rdbtls.RenewBefore(cr), caDuration(cr))) {
+		return caRotationReasonExpiring, nil
+	}
+	if !rdbtls.KeyMatchesPolicy(cr, cert) {
+		return caRotationReasonKeyPolicy, nil
+	}
+	return "", nil
+}
+
+// isAdminPasswordRotating returns true if a rotation of the admin password is in progress for the given
+// RethinkDBCluster.
+func isAdminPasswordRotating(cr *v1alpha1.RethinkDBCluster) bool {
+	rotation := cr.Status.AdminPasswordRotation
+	return rotation != nil && rotation.Stage == v1alpha1.AdminPasswordRotationApplying
+}
+
+// isCARotating returns true if a rotation of the CA is in progress for the given RethinkDBCluster.
+func isCARotating(cr *v1alpha1.RethinkDBCluster) bool {
+	rotation := cr.Status.CARotation
+	return rotation != nil && rotation.Stage != v1alpha1.CARotationCompleted
+}
+
+// nextCASecretName returns the name of the Secret holding the new CA during the first stage of a CA rotation.
+func nextCASecretName(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s-%s-next", cr.ObjectMeta.Name, RethinkDBCAKey)
+}
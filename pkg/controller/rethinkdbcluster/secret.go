@@ -15,44 +15,83 @@
 package rethinkdbcluster
 
 import (
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
 	"fmt"
 
 	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
 	tlsutil "github.com/operator-framework/operator-sdk/pkg/tls"
 	"github.com/sethvargo/go-password/password"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// certificateDNSNames returns the DNS names of the certificate with the given suffix, issued for the given
+// RethinkDBCluster. The names cover the Service the certificate is served on and, through a wildcard on the headless
+// cluster Service, the address of every server Pod. Pod IPs are not included, as servers are only reached by name.
+func certificateDNSNames(cr *v1alpha1.RethinkDBCluster, suffix string) []string {
+	servers := serverAddress(cr, "*")
+	switch suffix {
+	case RethinkDBClusterKey:
+		return append(serviceDNSNames(cr, clusterServiceName(cr)), servers)
+	case RethinkDBDriverKey:
+		return append(serviceDNSNames(cr, cr.ObjectMeta.Name), servers)
+	case RethinkDBHttpKey:
+		return append(serviceDNSNames(cr, adminServiceName(cr)), servers)
+	}
+	return serviceDNSNames(cr, cr.ObjectMeta.Name)
+}
+
+// generatePassword returns a new random password for a RethinkDB user.
+func generatePassword() (string, error) {
+	return password.Generate(16, 4, 0, false, false)
+}
+
+// newAdminRotationSecret creates a new Opaque secret for the given RethinkDBCluster, with the given password as the
+// password currently applied to the admin user.
+func newAdminRotationSecret(cr *v1alpha1.RethinkDBCluster, psswd []byte) *corev1.Secret {
+	secret := newSecret(cr)
+	secret.ObjectMeta.Name = adminRotationSecretName(cr)
+	secret.Data = map[string][]byte{
+		adminCurrentPasswordKey: psswd,
+	}
+	return secret
+}
+
 // newCASecret creates a new CA secret for the given RethinkDBCluster.
 func newCASecret(cr *v1alpha1.RethinkDBCluster, name string) (*corev1.Secret, error) {
 	secret := newTLSSecret(cr, name)
 
-	key, err := newPrivateKey()
+	key, err := rdbtls.NewPrivateKey(rdbtls.KeyAlgorithm(cr), rdbtls.KeySize(cr))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := rdbtls.NewSelfSignedCACertificate(key, caDuration(cr), rdbtls.CertificateOrganization(cr))
 	if err != nil {
 		return nil, err
 	}
 
-	cert, err := newSelfSignedCACertificate(key)
+	keyPEM, err := rdbtls.EncodePrivateKeyPEM(key)
 	if err != nil {
 		return nil, err
 	}
 
 	secret.Data = map[string][]byte{
-		corev1.TLSCertKey:       encodeCertificatePEM(cert),
-		corev1.TLSPrivateKeyKey: encodePrivateKeyPEM(key),
+		corev1.TLSCertKey:       rdbtls.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey: keyPEM,
+		TLSCACertKey:            rdbtls.EncodeCertificatePEM(cert),
 	}
 
 	return secret, nil
 }
 
-// newCertificateSecret creates a new secret for a TLS certificate.
-func newCertificateSecret(cr *v1alpha1.RethinkDBCluster, name string, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*corev1.Secret, error) {
+// newCertificateSecret creates a new secret for the TLS certificate with the given suffix.
+func newCertificateSecret(cr *v1alpha1.RethinkDBCluster, name string, suffix string, caCert *x509.Certificate, caKey crypto.Signer) (*corev1.Secret, error) {
 	secret := newTLSSecret(cr, name)
 
-	key, err := newPrivateKey()
+	key, err := rdbtls.NewPrivateKey(rdbtls.KeyAlgorithm(cr), rdbtls.KeySize(cr))
 	if err != nil {
 		return nil, err
 	}
@@ -61,22 +100,22 @@ func newCertificateSecret(cr *v1alpha1.RethinkDBCluster, name string, caCert *x5
 		CertName:     name,
 		CertType:     tlsutil.ClientAndServingCert,
 		CommonName:   name,
-		Organization: []string{cr.ObjectMeta.Namespace},
+		Organization: rdbtls.CertificateOrganization(cr),
 	}
 
-	dnsNames := []string{
-		cr.ObjectMeta.Name,
-		fmt.Sprintf("%s.%s.svc.cluster.local", cr.ObjectMeta.Name, cr.ObjectMeta.Namespace),
+	cert, err := rdbtls.NewSignedCertificate(cfg, certificateDNSNames(cr, suffix), rdbtls.CertificateDuration(cr), key, caCert, caKey)
+	if err != nil {
+		return nil, err
 	}
 
-	cert, err := newSignedCertificate(cfg, dnsNames, key, caCert, caKey)
+	keyPEM, err := rdbtls.EncodePrivateKeyPEM(key)
 	if err != nil {
 		return nil, err
 	}
 
 	secret.Data = map[string][]byte{
-		corev1.TLSCertKey:       encodeCertificatePEM(cert),
-		corev1.TLSPrivateKeyKey: encodePrivateKeyPEM(key),
+		corev1.TLSCertKey:       rdbtls.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey: keyPEM,
 	}
 
 	return secret, nil
@@ -104,7 +143,7 @@ func newTLSSecret(cr *v1alpha1.RethinkDBCluster, name string) *corev1.Secret {
 
 // newUserSecret creates a new Opaque secret for the given username and RethinkDBCluster.
 func newUserSecret(cr *v1alpha1.RethinkDBCluster, username string) (*corev1.Secret, error) {
-	psswd, err := password.Generate(16, 4, 0, false, false)
+	psswd, err := generatePassword()
 	if err != nil {
 		return nil, err
 	}
@@ -15,8 +15,6 @@
 package rethinkdbcluster
 
 import (
-	"fmt"
-
 	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,9 +23,23 @@ import (
 // newAdminService constructs a new admin Service object.
 func newAdminService(cr *v1alpha1.RethinkDBCluster) *corev1.Service {
 	svc := newService(cr)
-	svc.ObjectMeta.Name = fmt.Sprintf("%s-%s", cr.Name, RethinkDBAdminKey)
+	svc.ObjectMeta.Name = adminServiceName(cr)
+	svc.Spec.Ports = []corev1.ServicePort{
+		corev1.ServicePort{Port: RethinkDBHttpPort, Name: portName(cr, RethinkDBHttpKey)},
+	}
+	return svc
+}
+
+// newClusterService constructs a new headless cluster Service object.
+// The Service provides a stable DNS name for each server Pod, which is used by servers to join the cluster.
+func newClusterService(cr *v1alpha1.RethinkDBCluster) *corev1.Service {
+	svc := newService(cr)
+	svc.ObjectMeta.Name = clusterServiceName(cr)
+	svc.Spec.ClusterIP = corev1.ClusterIPNone
+	svc.Spec.PublishNotReadyAddresses = true
+	svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
 	svc.Spec.Ports = []corev1.ServicePort{
-		corev1.ServicePort{Port: RethinkDBHttpPort, Name: RethinkDBHttpKey},
+		corev1.ServicePort{Port: RethinkDBClusterPort, Name: portName(cr, RethinkDBClusterKey)},
 	}
 	return svc
 }
@@ -36,7 +48,7 @@ func newAdminService(cr *v1alpha1.RethinkDBCluster) *corev1.Service {
 func newDriverService(cr *v1alpha1.RethinkDBCluster) *corev1.Service {
 	svc := newService(cr)
 	svc.Spec.Ports = []corev1.ServicePort{
-		corev1.ServicePort{Port: RethinkDBDriverPort, Name: RethinkDBDriverKey},
+		corev1.ServicePort{Port: RethinkDBDriverPort, Name: portName(cr, RethinkDBDriverKey)},
 	}
 	return svc
 }
@@ -0,0 +1,246 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	reasonAsExpected          = "AsExpected"
+	reasonBootstrapping       = "Bootstrapping"
+	reasonCertificateMismatch = "CertificateMismatch"
+	reasonCertificatesPending = "CertificatesPending"
+	reasonClusterCreating     = "ClusterCreating"
+	reasonCriticalIssues      = "CriticalIssues"
+	reasonNoServersReady      = "NoServersReady"
+	reasonReconciling         = "Reconciling"
+	reasonReconcileDone       = "DesiredStateReached"
+	reasonScalingDown         = "ScalingDown"
+	reasonScalingUp           = "ScalingUp"
+	reasonServersNotReady     = "ServersNotReady"
+	reasonServersReady        = "ServersReady"
+	reasonSizeReached         = "SizeReached"
+	reasonStatusUnreachable   = "StatusUnreachable"
+	reasonTLSSecretsIssued    = "TLSSecretsIssued"
+	reasonTLSSecretsVerified  = "TLSSecretsVerified"
+	reasonUpgradePaused       = "UpgradePaused"
+	reasonUpgrading           = "Upgrading"
+	reasonVersionCurrent      = "VersionCurrent"
+)
+
+// clusterPhase returns the overall phase of the given RethinkDBCluster, based on the status conditions.
+func clusterPhase(cr *v1alpha1.RethinkDBCluster) v1alpha1.RethinkDBClusterPhase {
+	conditions := cr.Status.Conditions
+	available := v1alpha1.FindCondition(conditions, v1alpha1.ClusterConditionAvailable)
+
+	switch {
+	case available != nil && (available.Reason == reasonClusterCreating || available.Reason == reasonBootstrapping):
+		return v1alpha1.ClusterPhaseCreating
+	case v1alpha1.IsConditionTrue(conditions, v1alpha1.ClusterConditionScaling):
+		return v1alpha1.ClusterPhaseScaling
+	case v1alpha1.IsConditionTrue(conditions, v1alpha1.ClusterConditionUpgrading):
+		return v1alpha1.ClusterPhaseUpgrading
+	case !v1alpha1.IsConditionTrue(conditions, v1alpha1.ClusterConditionAvailable):
+		return v1alpha1.ClusterPhaseDegraded
+	case v1alpha1.IsConditionTrue(conditions, v1alpha1.ClusterConditionDegraded):
+		return v1alpha1.ClusterPhaseDegraded
+	}
+	return v1alpha1.ClusterPhaseRunning
+}
+
+// findCertificateStatus returns the status of the certificate in the Secret with the given name, or nil if the
+// certificate is not in the given TLS status.
+func findCertificateStatus(status *v1alpha1.RethinkDBTLSStatus, secret string) *v1alpha1.RethinkDBCertificateStatus {
+	if status == nil {
+		return nil
+	}
+	for i := range status.Certificates {
+		if status.Certificates[i].Secret == secret {
+			return &status.Certificates[i]
+		}
+	}
+	return nil
+}
+
+// newTLSStatus returns the TLS status for the given PEM encoded CA certificate and the certificate Secrets used by
+// the cluster.
+func newTLSStatus(caPEM []byte, secrets ...*corev1.Secret) (*v1alpha1.RethinkDBTLSStatus, error) {
+	caCert, err := rdbtls.ParsePEMEncodedCert(caPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	caNotAfter := metav1.NewTime(caCert.NotAfter)
+	status := &v1alpha1.RethinkDBTLSStatus{
+		CANotAfter:   &caNotAfter,
+		Certificates: []v1alpha1.RethinkDBCertificateStatus{},
+	}
+
+	for _, secret := range secrets {
+		cert, err := rdbtls.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return nil, err
+		}
+		status.Certificates = append(status.Certificates, v1alpha1.RethinkDBCertificateStatus{
+			Secret:   secret.Name,
+			NotAfter: metav1.NewTime(cert.NotAfter),
+		})
+	}
+	return status, nil
+}
+
+// parseServerVersion returns the RethinkDB version from the given server process version string.
+// The process version has the form "rethinkdb 2.3.6~0jessie (GCC 4.9.2)", which results in "2.3.6".
+func parseServerVersion(version string) string {
+	fields := strings.Fields(version)
+	if len(fields) < 2 {
+		return version
+	}
+	return strings.SplitN(fields[1], "~", 2)[0]
+}
+
+// setClusterConditions updates the ready server count, conditions and phase in the status of the given
+// RethinkDBCluster, based on the given server Pods and the current issues reported by the cluster. The given error
+// is the failure to query the cluster for its issues, if any, which marks the cluster as degraded.
+func setClusterConditions(cr *v1alpha1.RethinkDBCluster, servers []corev1.Pod, issues []admin.Issue, queryErr error) {
+	status := &cr.Status
+	serverCount := int32(len(servers))
+
+	// Server Pods that are deleted to restart them remain in the list of known servers.
+	knownCount := int32(len(status.Servers))
+	restarting := knownCount > serverCount || restartTarget(cr, servers) != nil
+
+	ready := int32(0)
+	for i := range servers {
+		if isPodReady(&servers[i]) {
+			ready++
+		}
+	}
+	status.ReadyServers = ready
+	status.ObservedGeneration = cr.ObjectMeta.Generation
+
+	// Available
+	available := v1alpha1.FindCondition(status.Conditions, v1alpha1.ClusterConditionAvailable)
+	if isBootstrapping(cr) {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionAvailable, corev1.ConditionFalse,
+			reasonBootstrapping, fmt.Sprintf("initializing the cluster from a backup: %s", status.Bootstrap.Message))
+	} else if ready > 0 {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionAvailable, corev1.ConditionTrue,
+			reasonServersReady, fmt.Sprintf("%d of %d servers are ready", ready, cr.Spec.Size))
+	} else if available == nil || available.Reason == reasonClusterCreating {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionAvailable, corev1.ConditionFalse,
+			reasonClusterCreating, "waiting for the first server to become ready")
+	} else {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionAvailable, corev1.ConditionFalse,
+			reasonNoServersReady, "no servers are ready")
+	}
+
+	// ScalingInProgress
+	scaling := true
+	if status.Drain != nil {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionScaling, corev1.ConditionTrue,
+			reasonScalingDown, fmt.Sprintf("removing server %s", status.Drain.Server))
+	} else if knownCount < cr.Spec.Size {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionScaling, corev1.ConditionTrue,
+			reasonScalingUp, fmt.Sprintf("%d of %d servers created", knownCount, cr.Spec.Size))
+	} else if serverCount > cr.Spec.Size {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionScaling, corev1.ConditionTrue,
+			reasonScalingDown, fmt.Sprintf("%d servers exceed the requested size of %d", serverCount, cr.Spec.Size))
+	} else {
+		scaling = false
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionScaling, corev1.ConditionFalse,
+			reasonSizeReached, "")
+	}
+
+	// UpgradeInProgress
+	upgrading := status.Upgrade != nil && !status.Upgrade.Paused
+	if status.Upgrade == nil {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionUpgrading, corev1.ConditionFalse,
+			reasonVersionCurrent, "")
+	} else if status.Upgrade.Paused {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionUpgrading, corev1.ConditionTrue,
+			reasonUpgradePaused, status.Upgrade.Message)
+	} else {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionUpgrading, corev1.ConditionTrue,
+			reasonUpgrading, fmt.Sprintf("%d of %d servers upgraded to version %s",
+				len(status.Upgrade.UpdatedServers), serverCount, status.Upgrade.TargetVersion))
+	}
+
+	// Degraded
+	critical := []string{}
+	for _, issue := range issues {
+		if issue.Critical {
+			critical = append(critical, issue.Description)
+		}
+	}
+	if len(critical) > 0 {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionDegraded, corev1.ConditionTrue,
+			reasonCriticalIssues, strings.Join(critical, "; "))
+	} else if queryErr != nil {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionDegraded, corev1.ConditionTrue,
+			reasonStatusUnreachable, fmt.Sprintf("unable to query cluster status: %v", queryErr))
+	} else if !scaling && !upgrading && !restarting && ready < serverCount && v1alpha1.IsConditionTrue(status.Conditions, v1alpha1.ClusterConditionAvailable) {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionDegraded, corev1.ConditionTrue,
+			reasonServersNotReady, fmt.Sprintf("%d of %d servers are ready", ready, serverCount))
+	} else {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionDegraded, corev1.ConditionFalse,
+			reasonAsExpected, "")
+	}
+
+	// Progressing
+	if status.Upgrade != nil && status.Upgrade.Paused {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionProgressing, corev1.ConditionFalse,
+			reasonUpgradePaused, status.Upgrade.Message)
+	} else if scaling || upgrading || restarting || ready < cr.Spec.Size || isBootstrapping(cr) {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionProgressing, corev1.ConditionTrue,
+			reasonReconciling, "")
+	} else {
+		v1alpha1.SetCondition(&status.Conditions, v1alpha1.ClusterConditionProgressing, corev1.ConditionFalse,
+			reasonReconcileDone, "")
+	}
+
+	status.Phase = clusterPhase(cr)
+}
+
+// setTLSCondition updates the TLSReady condition of the given RethinkDBCluster, based on the TLS status.
+// Existing certificates that do not match the certificates the operator would issue are reported in the condition.
+func setTLSCondition(cr *v1alpha1.RethinkDBCluster) {
+	mismatches := []string{}
+	if cr.Status.TLS != nil {
+		for _, cert := range cr.Status.TLS.Certificates {
+			if cert.Message != "" {
+				mismatches = append(mismatches, fmt.Sprintf("%s: %s", cert.Secret, cert.Message))
+			}
+		}
+	}
+
+	switch {
+	case len(mismatches) > 0:
+		v1alpha1.SetCondition(&cr.Status.Conditions, v1alpha1.ClusterConditionTLSReady, corev1.ConditionFalse, reasonCertificateMismatch,
+			strings.Join(mismatches, "; "))
+	case !rdbtls.IssuesCertificates(cr):
+		v1alpha1.SetCondition(&cr.Status.Conditions, v1alpha1.ClusterConditionTLSReady, corev1.ConditionTrue, reasonTLSSecretsVerified, "")
+	default:
+		v1alpha1.SetCondition(&cr.Status.Conditions, v1alpha1.ClusterConditionTLSReady, corev1.ConditionTrue, reasonTLSSecretsIssued, "")
+	}
+}
@@ -15,125 +15,125 @@
 package rethinkdbcluster
 
 import (
-	"crypto/rand"
-	"crypto/rsa"
+	"bytes"
+	"crypto/sha256"
 	"crypto/x509"
-	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/pem"
-	"errors"
-	"math"
-	"math/big"
 	"time"
 
-	tlsutil "github.com/operator-framework/operator-sdk/pkg/tls"
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
 	corev1 "k8s.io/api/core/v1"
 )
 
 const (
-	rsaKeySize   = 2048
-	duration365d = time.Hour * 24 * 365
+	// defaultCADuration is the lifetime of the generated CA certificate, unless set in the spec.
+	defaultCADuration = time.Hour * 24 * 365
 
 	// TLSCACertKey is the key for tls CA certificates.
-	TLSCACertKey = tlsutil.TLSCACertKey
+	TLSCACertKey = rdbtls.CACertKey
 
 	// TLSCertKey is the key for tls certificates.
-	TLSCertKey = corev1.TLSCertKey
+	TLSCertKey = rdbtls.CertKey
 )
 
-// newPrivateKey returns randomly generated RSA private key.
-func newPrivateKey() (*rsa.PrivateKey, error) {
-	return rsa.GenerateKey(rand.Reader, rsaKeySize)
-}
-
-// encodePrivateKeyPEM encodes the given private key pem and returns bytes (base64).
-func encodePrivateKeyPEM(key *rsa.PrivateKey) []byte {
-	return pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
+// appendCertificates returns a bundle of the given PEM encoded certificates.
+func appendCertificates(certs ...[]byte) []byte {
+	bundle := []byte{}
+	for _, cert := range certs {
+		bundle = append(bundle, cert...)
+		if len(cert) > 0 && cert[len(cert)-1] != '\n' {
+			bundle = append(bundle, '\n')
+		}
+	}
+	return bundle
 }
 
-// encodeCertificatePEM encodes the given certificate pem and returns bytes (base64).
-func encodeCertificatePEM(cert *x509.Certificate) []byte {
-	return pem.EncodeToMemory(&pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: cert.Raw,
-	})
-}
+// bundleContains returns true if the given PEM encoded bundle contains the given PEM encoded certificate.
+func bundleContains(bundle []byte, cert []byte) bool {
+	want, _ := pem.Decode(cert)
+	if want == nil {
+		return false
+	}
 
-// parsePEMEncodedCert parses a certificate from the given pemdata
-func parsePEMEncodedCert(pemdata []byte) (*x509.Certificate, error) {
-	decoded, _ := pem.Decode(pemdata)
-	if decoded == nil {
-		return nil, errors.New("no PEM data found")
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			return false
+		}
+		if bytes.Equal(block.Bytes, want.Bytes) {
+			return true
+		}
 	}
-	return x509.ParseCertificate(decoded.Bytes)
 }
 
-// parsePEMEncodedPrivateKey parses a private key from given pemdata
-func parsePEMEncodedPrivateKey(pemdata []byte) (*rsa.PrivateKey, error) {
-	decoded, _ := pem.Decode(pemdata)
-	if decoded == nil {
-		return nil, errors.New("no PEM data found")
+// caTrustBundle returns the PEM encoded bundle of trusted CA certificates from the given CA Secret.
+// During a CA rotation the bundle holds both the old and new CA. Secrets created before the bundle was introduced
+// only hold the CA certificate, and an existing CA Secret may only hold the chain of the CA.
+func caTrustBundle(caSecret *corev1.Secret) []byte {
+	bundle := caSecret.Data[TLSCACertKey]
+	if bundleContains(bundle, caSecret.Data[TLSCertKey]) {
+		return bundle
 	}
-	return x509.ParsePKCS1PrivateKey(decoded.Bytes)
+	return appendCertificates(caSecret.Data[TLSCertKey], bundle)
 }
 
-// newSelfSignedCACertificate returns a self-signed CA certificate based on given configuration and private key.
-// The certificate has one-year lease.
-func newSelfSignedCACertificate(key *rsa.PrivateKey) (*x509.Certificate, error) {
-	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
-	if err != nil {
-		return nil, err
+// dnsNamesMatch returns true if the given certificate has exactly the given DNS names, in any order.
+func dnsNamesMatch(cert *x509.Certificate, names []string) bool {
+	if len(cert.DNSNames) != len(names) {
+		return false
 	}
-	now := time.Now()
-	tmpl := x509.Certificate{
-		SerialNumber:          serial,
-		NotBefore:             now.UTC(),
-		NotAfter:              now.Add(duration365d).UTC(),
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		BasicConstraintsValid: true,
-		IsCA:                  true,
+	expected := map[string]bool{}
+	for _, name := range names {
+		expected[name] = true
 	}
-	certDERBytes, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, key.Public(), key)
-	if err != nil {
-		return nil, err
+	for _, name := range cert.DNSNames {
+		if !expected[name] {
+			return false
+		}
 	}
-	return x509.ParseCertificate(certDERBytes)
+	return true
 }
 
-// newSignedCertificate signs a certificate using the given private key, CA and returns a signed certificate.
-// The certificate could be used for both client and server auth.
-// The certificate has one-year lease.
-func newSignedCertificate(cfg *tlsutil.CertConfig, dnsNames []string, key *rsa.PrivateKey, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*x509.Certificate, error) {
-	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
-	if err != nil {
-		return nil, err
+// mergeCertificates returns the given PEM encoded bundle with the certificates from the given PEM encoded
+// certificates appended, skipping any certificate that is already in the bundle.
+func mergeCertificates(bundle []byte, certs []byte) []byte {
+	for {
+		var block *pem.Block
+		block, certs = pem.Decode(certs)
+		if block == nil {
+			return bundle
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert := pem.EncodeToMemory(block)
+		if !bundleContains(bundle, cert) {
+			bundle = appendCertificates(bundle, cert)
+		}
 	}
-	eku := []x509.ExtKeyUsage{}
-	switch cfg.CertType {
-	case tlsutil.ClientCert:
-		eku = append(eku, x509.ExtKeyUsageClientAuth)
-	case tlsutil.ServingCert:
-		eku = append(eku, x509.ExtKeyUsageServerAuth)
-	case tlsutil.ClientAndServingCert:
-		eku = append(eku, x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth)
-	}
-	certTmpl := x509.Certificate{
-		Subject: pkix.Name{
-			CommonName:   cfg.CommonName,
-			Organization: cfg.Organization,
-		},
-		DNSNames:     dnsNames,
-		SerialNumber: serial,
-		NotBefore:    caCert.NotBefore,
-		NotAfter:     time.Now().Add(duration365d).UTC(),
-		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage:  eku,
+}
+
+// needsRenewal returns true if the given certificate expires within the given renewal window.
+func needsRenewal(cert *x509.Certificate, renewBefore time.Duration) bool {
+	return time.Now().Add(renewBefore).After(cert.NotAfter)
+}
+
+// tlsRevision returns a short hash of the TLS mode of the given RethinkDBCluster, the given CA bundle and the
+// certificates in the given TLS Secrets. The revision changes whenever any of the certificates is re-issued or renewed,
+// the trusted CAs change or the TLS settings of the servers change.
+func tlsRevision(cr *v1alpha1.RethinkDBCluster, bundle []byte, secrets ...*corev1.Secret) string {
+	hash := sha256.New()
+	// The mode is only hashed when it differs from the defaults, so the revision of clusters with the default
+	// settings is unchanged.
+	if mode := tlsMode(cr); mode != "" {
+		hash.Write([]byte(mode))
 	}
-	certDERBytes, err := x509.CreateCertificate(rand.Reader, &certTmpl, caCert, key.Public(), caKey)
-	if err != nil {
-		return nil, err
+	hash.Write(bundle)
+	for _, secret := range secrets {
+		hash.Write(secret.Data[corev1.TLSCertKey])
 	}
-	return x509.ParseCertificate(certDERBytes)
+	return hex.EncodeToString(hash.Sum(nil))[:16]
 }
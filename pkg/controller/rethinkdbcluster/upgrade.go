@@ -0,0 +1,149 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbcluster
+
+import (
+	"strings"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// failingContainerReasons are the container waiting reasons that indicate a server will not start without intervention.
+var failingContainerReasons = []string{
+	"CrashLoopBackOff",
+	"CreateContainerConfigError",
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+}
+
+// allReplicasReady returns true when every table in the cluster reports all replicas ready.
+func allReplicasReady(ac admin.Client) (bool, error) {
+	tables, err := ac.TableStatus()
+	if err != nil {
+		return false, err
+	}
+
+	for _, table := range tables {
+		if !table.Status.AllReplicasReady {
+			log.Info("waiting for table replicas to become ready", "db", table.DB, "table", table.Name)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// containerFailure returns the reason and message if the RethinkDB container in the given Pod is failing to start.
+// The last return value will be false if the container is not failing.
+func containerFailure(pod *corev1.Pod) (string, string, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != RethinkDBApp || status.State.Waiting == nil {
+			continue
+		}
+		if util.ContainsString(failingContainerReasons, status.State.Waiting.Reason) {
+			return status.State.Waiting.Reason, status.State.Waiting.Message, true
+		}
+	}
+	return "", "", false
+}
+
+// isServerOutdated returns true if the RethinkDB container in the given server Pod does not run the requested image.
+func isServerOutdated(cr *v1alpha1.RethinkDBCluster, pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == RethinkDBApp {
+			return container.Image != serverImage(cr)
+		}
+	}
+	return false
+}
+
+// isServerRestarted returns true if the RethinkDB container in the given server Pod is ready and runs the requested
+// image. The registry is ignored when comparing images, as the container runtime may report the fully qualified name.
+func isServerRestarted(cr *v1alpha1.RethinkDBCluster, pod *corev1.Pod) bool {
+	image := serverImage(cr)
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == RethinkDBApp {
+			return status.Ready && strings.HasSuffix(status.Image, image)
+		}
+	}
+	return false
+}
+
+// isServerRejoined returns true when the given server is connected to the cluster and every table reports
+// all replicas ready.
+func isServerRejoined(ac admin.Client, server string) (bool, error) {
+	servers, err := ac.ServerStatus()
+	if err != nil {
+		return false, err
+	}
+
+	connected := false
+	for _, status := range servers {
+		if status.Name == server {
+			connected = true
+			break
+		}
+	}
+	if !connected {
+		log.Info("waiting for server to rejoin the cluster", "server", server)
+		return false, nil
+	}
+
+	return allReplicasReady(ac)
+}
+
+// setServerImage sets the requested image on the RethinkDB container in the given server Pod.
+func setServerImage(cr *v1alpha1.RethinkDBCluster, pod *corev1.Pod) {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == RethinkDBApp {
+			pod.Spec.Containers[i].Image = serverImage(cr)
+		}
+	}
+}
+
+// upgradeTarget returns the next server Pod to upgrade, or nil if all server Pods run the requested image.
+// An upgrade in progress is resumed with the same server, otherwise the outdated server with the highest ordinal
+// is upgraded first.
+func upgradeTarget(cr *v1alpha1.RethinkDBCluster, servers []corev1.Pod) *corev1.Pod {
+	if cr.Status.Upgrade != nil && cr.Status.Upgrade.Server != "" {
+		for i := range servers {
+			if servers[i].Name == cr.Status.Upgrade.Server {
+				return &servers[i]
+			}
+		}
+	}
+
+	var target *corev1.Pod
+	highest := int32(-1)
+	for i := range servers {
+		pod := &servers[i]
+		if !isServerOutdated(cr, pod) {
+			continue
+		}
+
+		ordinal, ok := serverOrdinal(cr, pod)
+		if !ok {
+			ordinal = -1
+		}
+		if target == nil || ordinal > highest {
+			target = pod
+			highest = ordinal
+		}
+	}
+	return target
+}
@@ -15,9 +15,18 @@
 package rethinkdbcluster
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -30,14 +39,20 @@ const (
 	// RethinkDBAppKey is the key for the RethinkDB app.
 	RethinkDBAppKey = "app"
 
+	// RethinkDBBootstrapKey is the key for the RethinkDB bootstrap assets.
+	RethinkDBBootstrapKey = "bootstrap"
+
 	// RethinkDBCAKey is the key for the RethinkDB CA TLS assets.
-	RethinkDBCAKey = "ca"
+	RethinkDBCAKey = rdbtls.CAKey
 
 	// RethinkDBClientKey is the key for the RethinkDB client TLS assets.
-	RethinkDBClientKey = "client"
+	RethinkDBClientKey = rdbtls.ClientKey
+
+	// RethinkDBClusterDomain is the default DNS domain of the Kubernetes cluster.
+	RethinkDBClusterDomain = admin.DefaultClusterDomain
 
 	// RethinkDBClusterKey is the key for the RethinkDB cluster TLS assets.
-	RethinkDBClusterKey = "cluster"
+	RethinkDBClusterKey = rdbtls.ClusterKey
 
 	// RethinkDBClusterPort is the default RethinkDB cluster port.
 	RethinkDBClusterPort = 29015
@@ -48,17 +63,20 @@ const (
 	// RethinkDBDataPath is the default path for RethinkDB data.
 	RethinkDBDataPath = "/data"
 
+	// RethinkDBDataSuffix is the name suffix for RethinkDB data volume claims.
+	RethinkDBDataSuffix = "data"
+
 	// RethinkDBDriverKey is the key for the RethinkDB driver TLS assets.
-	RethinkDBDriverKey = "driver"
+	RethinkDBDriverKey = rdbtls.DriverKey
 
 	// RethinkDBDriverPort is the default RethinkDB driver port.
-	RethinkDBDriverPort = 28015
+	RethinkDBDriverPort = admin.DriverPort
 
 	// RethinkDBExePath is the default RethinkDB executable path.
 	RethinkDBExePath = "/usr/bin/rethinkdb"
 
 	// RethinkDBHttpKey is the key for the RethinkDB http TLS assets.
-	RethinkDBHttpKey = "http"
+	RethinkDBHttpKey = rdbtls.HTTPKey
 
 	// RethinkDBHttpPort is the default RethinkDB http (web-admin) port.
 	RethinkDBHttpPort = 8080
@@ -70,21 +88,50 @@ const (
 	RethinkDBImageTag = "latest"
 
 	// RethinkDBPasswordKey is the key for the password field.
-	RethinkDBPasswordKey = "password"
+	RethinkDBPasswordKey = admin.PasswordKey
 
 	// RethinkDBPasswordEnv is the key for the RethinkDB password environment variable.
 	RethinkDBPasswordEnv = "RETHINKDB_PASSWORD"
 
+	// RethinkDBRotateAdminPasswordAnnotation is the annotation on a RethinkDBCluster that starts a rotation of the
+	// admin password whenever its value changes.
+	RethinkDBRotateAdminPasswordAnnotation = "rethinkdb.com/rotate-admin-password"
+
+	// RethinkDBRotateCAAnnotation is the annotation on a RethinkDBCluster that starts a rotation of the cluster CA
+	// whenever its value changes.
+	RethinkDBRotateCAAnnotation = "rethinkdb.com/rotate-ca"
+
 	// RethinkDBTLSPath is the default path for RethinkDB TLS assets.
 	RethinkDBTLSPath = "/etc/rethinkdb/tls"
 
+	// RethinkDBTLSRevisionAnnotation is the annotation on server Pods with the revision of the mounted certificates.
+	RethinkDBTLSRevisionAnnotation = "rethinkdb.com/tls-revision"
+
 	// RethinkDBTLSSecretsKey is the key for the RethinkDB TLS secrets volume.
 	RethinkDBTLSSecretsKey = "tls-secrets"
 
 	// RethinkDBUsernameKey is the key for the username field.
-	RethinkDBUsernameKey = "username"
+	RethinkDBUsernameKey = admin.UsernameKey
 )
 
+// adminServiceName returns the name of the web-admin Service for the given RethinkDBCluster.
+func adminServiceName(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, RethinkDBAdminKey)
+}
+
+// caDuration returns the lifetime of the generated CA certificate for the given RethinkDBCluster.
+func caDuration(cr *v1alpha1.RethinkDBCluster) time.Duration {
+	if cr.Spec.TLS != nil && cr.Spec.TLS.CADuration != nil && cr.Spec.TLS.CADuration.Duration > 0 {
+		return cr.Spec.TLS.CADuration.Duration
+	}
+	return defaultCADuration
+}
+
+// clusterServiceName returns the name of the headless cluster Service for the given RethinkDBCluster.
+func clusterServiceName(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, RethinkDBClusterKey)
+}
+
 // defaultLabels returns the default set of labels for the cluster.
 func defaultLabels(cr *v1alpha1.RethinkDBCluster) map[string]string {
 	return map[string]string{
@@ -93,6 +140,47 @@ func defaultLabels(cr *v1alpha1.RethinkDBCluster) map[string]string {
 	}
 }
 
+// drainDestinations returns the names of the servers the replicas of the given drained server Pod are moved to. Only
+// servers that are kept are returned, so the replicas are not moved again when the servers with higher ordinals are
+// drained.
+func drainDestinations(cr *v1alpha1.RethinkDBCluster, pod corev1.Pod, servers []corev1.Pod) []string {
+	destinations := []string{}
+	for i := range servers {
+		ordinal, ok := serverOrdinal(cr, &servers[i])
+		if ok && ordinal < cr.Spec.Size && servers[i].Name != pod.Name {
+			destinations = append(destinations, serverName(servers[i].Name))
+		}
+	}
+	return destinations
+}
+
+// drainTarget returns the server Pod that should be drained and removed when scaling down the cluster.
+// A drain that is already in progress is resumed, otherwise the server with the highest ordinal is chosen.
+func drainTarget(cr *v1alpha1.RethinkDBCluster, servers []corev1.Pod) *corev1.Pod {
+	if cr.Status.Drain != nil {
+		for i := range servers {
+			if servers[i].Name == cr.Status.Drain.Server {
+				return &servers[i]
+			}
+		}
+	}
+
+	var target *corev1.Pod
+	var highest int32 = -1
+	for i := range servers {
+		ordinal, ok := serverOrdinal(cr, &servers[i])
+		if !ok {
+			// Always remove servers that were not created with an ordinal first.
+			return &servers[i]
+		}
+		if ordinal > highest {
+			highest = ordinal
+			target = &servers[i]
+		}
+	}
+	return target
+}
+
 // labelsForCluster returns the labels for all cluster resources.
 func labelsForCluster(cr *v1alpha1.RethinkDBCluster) map[string]string {
 	labels := defaultLabels(cr)
@@ -102,6 +190,80 @@ func labelsForCluster(cr *v1alpha1.RethinkDBCluster) map[string]string {
 	return labels
 }
 
+// nextServerOrdinal returns the lowest ordinal that is not in use by any of the given server Pods.
+func nextServerOrdinal(cr *v1alpha1.RethinkDBCluster, servers []corev1.Pod) int32 {
+	used := map[int32]bool{}
+	for _, pod := range servers {
+		if ordinal, ok := serverOrdinal(cr, &pod); ok {
+			used[ordinal] = true
+		}
+	}
+
+	var ordinal int32
+	for used[ordinal] {
+		ordinal++
+	}
+	return ordinal
+}
+
+// parseOrdinal returns the ordinal suffix of the given name, after the given prefix.
+// The second return value will be false if the name does not contain a valid ordinal.
+func parseOrdinal(name string, prefix string) (int32, bool) {
+	if !strings.HasPrefix(name, prefix) {
+		return -1, false
+	}
+	ordinal, err := strconv.ParseInt(strings.TrimPrefix(name, prefix), 10, 32)
+	if err != nil || ordinal < 0 {
+		return -1, false
+	}
+	return int32(ordinal), true
+}
+
+// portName returns the name of the Service and container port with the given suffix. The name has the protocol as
+// a prefix, so a service mesh can detect whether the port serves TLS or plaintext.
+func portName(cr *v1alpha1.RethinkDBCluster, suffix string) string {
+	if suffix == RethinkDBHttpKey {
+		if rdbtls.Enabled(cr, suffix) {
+			return "https"
+		}
+		return "http"
+	}
+
+	if rdbtls.Enabled(cr, suffix) {
+		return fmt.Sprintf("tls-%s", suffix)
+	}
+	return fmt.Sprintf("tcp-%s", suffix)
+}
+
+// requiresClientCertificates returns true if clients must present a certificate signed by the cluster CA to connect
+// to the driver port of the given RethinkDBCluster.
+func requiresClientCertificates(cr *v1alpha1.RethinkDBCluster) bool {
+	return cr.Spec.TLS != nil && cr.Spec.TLS.RequireClientCertificates && rdbtls.Enabled(cr, RethinkDBDriverKey)
+}
+
+// requestsForClusterLabel maps an object to a reconcile request for the RethinkDBCluster named by the cluster label.
+func requestsForClusterLabel(obj handler.MapObject) []reconcile.Request {
+	name, ok := obj.Meta.GetLabels()[RethinkDBClusterKey]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      name,
+		Namespace: obj.Meta.GetNamespace(),
+	}}}
+}
+
+// serviceDNSNames returns the DNS names of the Service with the given name in the namespace of the given
+// RethinkDBCluster, from the short name to the fully qualified name.
+func serviceDNSNames(cr *v1alpha1.RethinkDBCluster, name string) []string {
+	return []string{
+		name,
+		fmt.Sprintf("%s.%s", name, cr.ObjectMeta.Namespace),
+		fmt.Sprintf("%s.%s.svc", name, cr.ObjectMeta.Namespace),
+		fmt.Sprintf("%s.%s.svc.%s", name, cr.ObjectMeta.Namespace, cr.Spec.ClusterDomain),
+	}
+}
+
 // setDefaults sets the default vaules for the spec and returns true if the spec was changed.
 func setDefaults(cr *v1alpha1.RethinkDBCluster) bool {
 	changed := false
@@ -114,5 +276,35 @@ func setDefaults(cr *v1alpha1.RethinkDBCluster) bool {
 		spec.Version = RethinkDBImageTag
 		changed = true
 	}
+	if strings.TrimSpace(spec.ClusterDomain) == "" {
+		spec.ClusterDomain = RethinkDBClusterDomain
+		changed = true
+	}
 	return changed
 }
+
+// tlsMode returns a summary of the TLS settings of the server Pods for the given RethinkDBCluster that differ from
+// the defaults. The summary is empty when TLS is enabled on all ports and client certificates are not required.
+func tlsMode(cr *v1alpha1.RethinkDBCluster) string {
+	mode := []string{}
+	for _, suffix := range []string{RethinkDBClusterKey, RethinkDBDriverKey, RethinkDBHttpKey} {
+		if !rdbtls.Enabled(cr, suffix) {
+			mode = append(mode, fmt.Sprintf("%s=false", suffix))
+		}
+	}
+	if requiresClientCertificates(cr) {
+		mode = append(mode, "clientAuth=true")
+	}
+	return strings.Join(mode, ",")
+}
+
+// usedClaims returns the set of claim names in use by the given Pods.
+func usedClaims(pods []corev1.Pod) map[string]bool {
+	used := map[string]bool{}
+	for _, pod := range pods {
+		if claimName := claimNameForPod(&pod); claimName != "" {
+			used[claimName] = true
+		}
+	}
+	return used
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/snapshot"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -41,6 +43,7 @@ func newEmptyDirVolume(name string) corev1.Volume {
 }
 
 // newProjectedVolume creates a new Projected volume with the given name.
+// The trusted CA certificates are projected from the CA ConfigMap, so that the CA private key is never mounted.
 func newProjectedVolume(cr *v1alpha1.RethinkDBCluster, name string) corev1.Volume {
 	return corev1.Volume{
 		Name: name,
@@ -48,11 +51,11 @@ func newProjectedVolume(cr *v1alpha1.RethinkDBCluster, name string) corev1.Volum
 			Projected: &corev1.ProjectedVolumeSource{
 				Sources: []corev1.VolumeProjection{
 					corev1.VolumeProjection{
-						Secret: &corev1.SecretProjection{
-							LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, RethinkDBCAKey)},
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: rdbtls.CAConfigMapName(cr)},
 							Items: []corev1.KeyToPath{
 								corev1.KeyToPath{
-									Key:  corev1.TLSCertKey,
+									Key:  TLSCACertKey,
 									Path: fmt.Sprintf("%s.crt", RethinkDBCAKey),
 								},
 							},
@@ -81,7 +84,7 @@ func newPVCVolume(name string, claimName string) corev1.Volume {
 func newTLSVolumeProjection(cr *v1alpha1.RethinkDBCluster, name string) corev1.VolumeProjection {
 	return corev1.VolumeProjection{
 		Secret: &corev1.SecretProjection{
-			LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, name)},
+			LocalObjectReference: corev1.LocalObjectReference{Name: rdbtls.SecretName(cr, name)},
 			Items: []corev1.KeyToPath{
 				corev1.KeyToPath{
 					Key:  corev1.TLSCertKey,
@@ -96,31 +99,64 @@ func newTLSVolumeProjection(cr *v1alpha1.RethinkDBCluster, name string) corev1.V
 	}
 }
 
-// newPVC creates a new PersistentVolumeClaim for the cluster.
-func newPVC(cr *v1alpha1.RethinkDBCluster) *corev1.PersistentVolumeClaim {
+// claimNameForOrdinal returns the name of the PersistentVolumeClaim for the server with the given ordinal.
+func claimNameForOrdinal(cr *v1alpha1.RethinkDBCluster, ordinal int32) string {
+	return fmt.Sprintf("%s-%s-%d", cr.ObjectMeta.Name, RethinkDBDataSuffix, ordinal)
+}
+
+// claimOrdinal returns the server ordinal for the PersistentVolumeClaim with the given name.
+// The second return value will be false if the claim was not created for a server ordinal.
+func claimOrdinal(cr *v1alpha1.RethinkDBCluster, name string) (int32, bool) {
+	return parseOrdinal(name, fmt.Sprintf("%s-%s-", cr.ObjectMeta.Name, RethinkDBDataSuffix))
+}
+
+// claimNameForPod returns the name of the PersistentVolumeClaim backing the data volume for the given Pod.
+// An empty string is returned if the data volume is not backed by a claim.
+func claimNameForPod(pod *corev1.Pod) string {
+	for _, vol := range pod.Spec.Volumes {
+		if vol.Name == RethinkDBDataKey && vol.PersistentVolumeClaim != nil {
+			return vol.PersistentVolumeClaim.ClaimName
+		}
+	}
+	return ""
+}
+
+// newDataVolume creates the data volume for a server Pod.
+// The volume is backed by the given claim when persistent volumes are enabled, otherwise an EmptyDir is used.
+func newDataVolume(cr *v1alpha1.RethinkDBCluster, claimName string) corev1.Volume {
+	if isPVEnabled(cr) {
+		return newPVCVolume(RethinkDBDataKey, claimName)
+	}
+	return newEmptyDirVolume(RethinkDBDataKey)
+}
+
+// newPVC creates a new PersistentVolumeClaim for the server with the given ordinal.
+// The claim is intentionally not owned by the RethinkDBCluster so that data survives the removal of the cluster.
+// While the cluster is bootstrapped from volume snapshots, the claim is provisioned from the snapshot of the server.
+func newPVC(cr *v1alpha1.RethinkDBCluster, ordinal int32) *corev1.PersistentVolumeClaim {
 	var pvcSpec corev1.PersistentVolumeClaimSpec
 	if isPVEnabled(cr) {
-		pvcSpec = *cr.Spec.Pod.PersistentVolumeClaimSpec
+		pvcSpec = *cr.Spec.Pod.PersistentVolumeClaimSpec.DeepCopy()
+	}
+	if name := bootstrapSnapshot(cr, ordinal); name != "" {
+		pvcSpec.DataSource = snapshot.DataSource(name)
 	}
 
 	return &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: fmt.Sprintf("%s-", cr.ObjectMeta.Name),
-			Namespace:    cr.ObjectMeta.Namespace,
-			Labels:       cr.ObjectMeta.Labels,
+			Name:      claimNameForOrdinal(cr, ordinal),
+			Namespace: cr.ObjectMeta.Namespace,
+			Labels:    labelsForCluster(cr),
 		},
 		Spec: pvcSpec,
 	}
 }
 
 // newVolumes creates the volumes used by the application.
-func newVolumes(cr *v1alpha1.RethinkDBCluster) []corev1.Volume {
-	volumes := []corev1.Volume{
+// The data volume is backed by the given claim when persistent volumes are enabled.
+func newVolumes(cr *v1alpha1.RethinkDBCluster, claimName string) []corev1.Volume {
+	return []corev1.Volume{
 		newProjectedVolume(cr, RethinkDBTLSSecretsKey),
+		newDataVolume(cr, claimName),
 	}
-
-	// TODO: Handle persistent volumes for RethinkDB data!
-	volumes = append(volumes, newEmptyDirVolume(RethinkDBDataKey))
-
-	return volumes
 }
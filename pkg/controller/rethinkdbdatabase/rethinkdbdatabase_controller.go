@@ -0,0 +1,274 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbdatabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rethinkdbdatabase")
+
+const (
+	// clusterRequeueDelay is the delay before checking whether the cluster is available again.
+	clusterRequeueDelay = time.Second * 10
+
+	// databaseFinalizer is the finalizer that applies the retention policy before the RethinkDBDatabase is removed.
+	databaseFinalizer = "rethinkdb.com/database"
+)
+
+// Reasons for the events recorded and the conditions set on a RethinkDBDatabase.
+const (
+	reasonClusterNotFound = "ClusterNotFound"
+	reasonClusterNotReady = "ClusterNotReady"
+	reasonCreated         = "Created"
+	reasonDeleted         = "Deleted"
+	reasonInvalidSpec     = "InvalidSpec"
+	reasonReconcileFailed = "ReconcileFailed"
+	reasonRetained        = "Retained"
+	reasonSynced          = "Synced"
+)
+
+// Add creates a new RethinkDBDatabase Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRethinkDBDatabase{
+		client:         mgr.GetClient(),
+		scheme:         mgr.GetScheme(),
+		recorder:       mgr.GetRecorder("rethinkdbdatabase-controller"),
+		newAdminClient: admin.NewClientForCluster,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rethinkdbdatabase-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RethinkDBDatabase
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBDatabase{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBClusters and requeue the RethinkDBDatabases in the cluster, so the databases are
+	// created once the cluster is available.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBCluster{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: util.RequestsForCluster(mgr.GetClient(), &rethinkdbv1alpha1.RethinkDBDatabaseList{}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRethinkDBDatabase{}
+
+// ReconcileRethinkDBDatabase reconciles a RethinkDBDatabase object
+type ReconcileRethinkDBDatabase struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+
+	// newAdminClient opens admin connections to the RethinkDB cluster.
+	newAdminClient admin.ClientFunc
+}
+
+// Reconcile ensures the database for the given RethinkDBDatabase request exists in the RethinkDB cluster.
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileRethinkDBDatabase) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("namespace", request.Namespace, "name", request.Name)
+	reqLogger.Info("reconciling RethinkDBDatabase")
+
+	// Fetch the RethinkDBDatabase instance
+	db := &rethinkdbv1alpha1.RethinkDBDatabase{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, db)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// Apply the retention policy before the resource is removed
+	if db.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.finalizeDatabase(db)
+	}
+
+	if !util.ContainsString(db.Finalizers, databaseFinalizer) {
+		reqLogger.Info("adding finalizer")
+		db.Finalizers = append(db.Finalizers, databaseFinalizer)
+		return reconcile.Result{Requeue: true}, r.client.Update(context.TODO(), db)
+	}
+
+	// Keep a copy of the observed status, the status is only updated at the end of the reconcile if it has changed.
+	observed := db.Status.DeepCopy()
+
+	result, err := r.reconcileDatabase(db)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile database")
+		rethinkdbv1alpha1.SetCondition(&db.Status.Conditions, rethinkdbv1alpha1.DatabaseConditionReady, corev1.ConditionFalse, reasonReconcileFailed, err.Error())
+		r.recorder.Eventf(db, corev1.EventTypeWarning, reasonReconcileFailed, "Unable to reconcile database: %v", err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(observed, &db.Status) {
+		if updateErr := r.client.Status().Update(context.TODO(), db); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
+
+// dropDatabase drops the database of the given RethinkDBDatabase and all of its tables from the given cluster.
+func (r *ReconcileRethinkDBDatabase) dropDatabase(db *rethinkdbv1alpha1.RethinkDBDatabase, cluster *rethinkdbv1alpha1.RethinkDBCluster) error {
+	adminClient, err := r.newAdminClient(r.client, cluster)
+	if err != nil {
+		return err
+	}
+	defer adminClient.Close()
+
+	names, err := adminClient.DBList()
+	if err != nil {
+		return err
+	}
+	if !util.ContainsString(names, db.Status.Name) {
+		return nil
+	}
+
+	log.Info("dropping database", "database", db.Status.Name)
+	if err = adminClient.DropDB(db.Status.Name); err != nil {
+		return err
+	}
+	r.recorder.Eventf(db, corev1.EventTypeNormal, reasonDeleted, "Dropped database %s", db.Status.Name)
+	return nil
+}
+
+// finalizeDatabase applies the retention policy of the given RethinkDBDatabase and removes the finalizer. The
+// database is only dropped with the Delete retention policy, and is left in place when the cluster no longer exists.
+func (r *ReconcileRethinkDBDatabase) finalizeDatabase(db *rethinkdbv1alpha1.RethinkDBDatabase) error {
+	if !util.ContainsString(db.Finalizers, databaseFinalizer) {
+		return nil
+	}
+
+	if db.Status.Name != "" {
+		if db.Spec.RetentionPolicy == rethinkdbv1alpha1.RetentionPolicyDelete {
+			cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+			err := r.client.Get(context.TODO(), util.ClusterName(db), cluster)
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+
+			if err == nil && cluster.DeletionTimestamp == nil {
+				if err = r.dropDatabase(db, cluster); err != nil {
+					return err
+				}
+			}
+		} else {
+			r.recorder.Eventf(db, corev1.EventTypeNormal, reasonRetained, "Retained database %s", db.Status.Name)
+		}
+	}
+
+	log.Info("removing finalizer", "namespace", db.Namespace, "name", db.Name)
+	db.Finalizers = util.RemoveString(db.Finalizers, databaseFinalizer)
+	return r.client.Update(context.TODO(), db)
+}
+
+// reconcileDatabase ensures the database for the given RethinkDBDatabase exists in the referenced cluster.
+func (r *ReconcileRethinkDBDatabase) reconcileDatabase(db *rethinkdbv1alpha1.RethinkDBDatabase) (reconcile.Result, error) {
+	if invalid := validateDatabase(db); invalid != nil {
+		r.setNotReady(db, reasonInvalidSpec, invalid.Error())
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), util.ClusterName(db), cluster)
+	if err != nil && errors.IsNotFound(err) {
+		// The cluster watch requeues the request once the cluster is created.
+		r.setNotReady(db, reasonClusterNotFound, fmt.Sprintf("RethinkDBCluster %s not found", util.ClusterName(db)))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !rethinkdbv1alpha1.IsConditionTrue(cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable) {
+		r.setNotReady(db, reasonClusterNotReady, fmt.Sprintf("RethinkDBCluster %s is not available", util.ClusterName(db)))
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	}
+
+	adminClient, err := r.newAdminClient(r.client, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer adminClient.Close()
+
+	names, err := adminClient.DBList()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	name := databaseName(db)
+	if !util.ContainsString(names, name) {
+		log.Info("creating database", "database", name)
+		if err = adminClient.CreateDB(name); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(db, corev1.EventTypeNormal, reasonCreated, "Created database %s", name)
+	}
+	db.Status.Name = name
+
+	rethinkdbv1alpha1.SetCondition(&db.Status.Conditions, rethinkdbv1alpha1.DatabaseConditionReady, corev1.ConditionTrue, reasonSynced,
+		fmt.Sprintf("Database %s exists", name))
+	return reconcile.Result{}, nil
+}
+
+// setNotReady marks the given RethinkDBDatabase as not ready with the given reason and message.
+func (r *ReconcileRethinkDBDatabase) setNotReady(db *rethinkdbv1alpha1.RethinkDBDatabase, reason string, message string) {
+	log.Info("database not ready", "namespace", db.Namespace, "name", db.Name, "reason", reason)
+	rethinkdbv1alpha1.SetCondition(&db.Status.Conditions, rethinkdbv1alpha1.DatabaseConditionReady, corev1.ConditionFalse, reason, message)
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbdatabase
+
+import (
+	"fmt"
+	"regexp"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+)
+
+// validName matches the names RethinkDB allows for databases.
+var validName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// databaseName returns the name of the database for the given RethinkDBDatabase.
+func databaseName(db *rethinkdbv1alpha1.RethinkDBDatabase) string {
+	if db.Spec.Name != "" {
+		return db.Spec.Name
+	}
+	return db.Name
+}
+
+// validateDatabase returns an error if the spec of the given RethinkDBDatabase can not be applied to the cluster.
+func validateDatabase(db *rethinkdbv1alpha1.RethinkDBDatabase) error {
+	if err := util.ValidateClusterReference(db); err != nil {
+		return err
+	}
+
+	name := databaseName(db)
+	if name == admin.SystemDB {
+		return fmt.Errorf("database %s is managed by the cluster", admin.SystemDB)
+	}
+	if !validName.MatchString(name) {
+		return fmt.Errorf("database name %s may only contain letters, numbers and underscores", name)
+	}
+	if db.Status.Name != "" && db.Status.Name != name {
+		return fmt.Errorf("database name can not be changed from %s to %s", db.Status.Name, name)
+	}
+
+	switch db.Spec.RetentionPolicy {
+	case "", rethinkdbv1alpha1.RetentionPolicyRetain, rethinkdbv1alpha1.RetentionPolicyDelete:
+	default:
+		return fmt.Errorf("unknown retention policy %s", db.Spec.RetentionPolicy)
+	}
+	return nil
+}
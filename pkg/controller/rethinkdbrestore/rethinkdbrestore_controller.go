@@ -0,0 +1,294 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbrestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rethinkdbrestore")
+
+// clusterRequeueDelay is the delay before checking whether the cluster is available again.
+const clusterRequeueDelay = time.Second * 10
+
+// Reasons for the events recorded and the conditions set on a RethinkDBRestore.
+const (
+	reasonClusterNotFound = "ClusterNotFound"
+	reasonClusterNotReady = "ClusterNotReady"
+	reasonCreated         = "Created"
+	reasonInvalidSpec     = "InvalidSpec"
+	reasonJobNotFound     = "JobNotFound"
+	reasonPending         = "Pending"
+	reasonReconcileFailed = "ReconcileFailed"
+	reasonRestoreFailed   = "RestoreFailed"
+	reasonRunning         = "Running"
+	reasonSucceeded       = "Succeeded"
+)
+
+// Add creates a new RethinkDBRestore Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRethinkDBRestore{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetRecorder("rethinkdbrestore-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rethinkdbrestore-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RethinkDBRestore
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBRestore{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the restore Jobs to record the progress and outcome of the restore
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &rethinkdbv1alpha1.RethinkDBRestore{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBClusters and requeue the RethinkDBRestores into the cluster, so the archive is
+	// restored once the cluster is available.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBCluster{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: util.RequestsForCluster(mgr.GetClient(), &rethinkdbv1alpha1.RethinkDBRestoreList{}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRethinkDBRestore{}
+
+// ReconcileRethinkDBRestore reconciles a RethinkDBRestore object
+type ReconcileRethinkDBRestore struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile runs the restore Job for the given RethinkDBRestore request once and records its progress and outcome
+// in the status.
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileRethinkDBRestore) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("namespace", request.Namespace, "name", request.Name)
+	reqLogger.Info("reconciling RethinkDBRestore")
+
+	// Fetch the RethinkDBRestore instance
+	restore := &rethinkdbv1alpha1.RethinkDBRestore{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, restore)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// A restore runs once, the Job is kept for its logs after the outcome has been recorded.
+	if isRestoreFinished(restore) {
+		return reconcile.Result{}, nil
+	}
+
+	// Keep a copy of the observed status, the status is only updated at the end of the reconcile if it has changed.
+	observed := restore.Status.DeepCopy()
+
+	result, err := r.reconcileRestore(restore)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile restore")
+		r.recorder.Eventf(restore, corev1.EventTypeWarning, reasonReconcileFailed, "Unable to reconcile restore: %v", err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(observed, &restore.Status) {
+		if updateErr := r.client.Status().Update(context.TODO(), restore); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
+
+// createJob creates the restore Job for the given RethinkDBRestore once the cluster is available.
+func (r *ReconcileRethinkDBRestore) createJob(restore *rethinkdbv1alpha1.RethinkDBRestore) (reconcile.Result, error) {
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), util.ClusterName(restore), cluster)
+	if err != nil && errors.IsNotFound(err) {
+		// The cluster watch requeues the request once the cluster is created.
+		r.setProgressing(restore, corev1.ConditionFalse, reasonClusterNotFound, fmt.Sprintf("RethinkDBCluster %s not found", util.ClusterName(restore)))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !rethinkdbv1alpha1.IsConditionTrue(cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable) {
+		r.setProgressing(restore, corev1.ConditionFalse, reasonClusterNotReady, fmt.Sprintf("RethinkDBCluster %s is not available", util.ClusterName(restore)))
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	}
+
+	job := newRestoreJob(restore, cluster)
+	if err = controllerutil.SetControllerReference(restore, job, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	log.Info("creating restore job", "namespace", job.Namespace, "name", job.Name)
+	if err = r.client.Create(context.TODO(), job); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(restore, corev1.EventTypeNormal, reasonCreated, "Created job %s to restore %s", job.Name, restore.Spec.Source.Archive)
+
+	restore.Status.JobName = job.Name
+	r.setProgressing(restore, corev1.ConditionTrue, reasonPending, fmt.Sprintf("Job %s is pending", job.Name))
+	return reconcile.Result{}, nil
+}
+
+// finishRestore records the outcome of the given finished restore Job on the given RethinkDBRestore.
+func (r *ReconcileRethinkDBRestore) finishRestore(restore *rethinkdbv1alpha1.RethinkDBRestore, job *batchv1.Job) error {
+	pods := &corev1.PodList{}
+	opts := &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{"job-name": job.Name}),
+	}
+	if err := r.client.List(context.TODO(), opts, pods); err != nil {
+		return err
+	}
+
+	result := dump.ReadResult(pods.Items)
+	if result == nil {
+		result = &dump.Result{}
+	}
+	restore.Status.CompletionTime = dump.JobFinishTime(job)
+	restore.Status.Tables = result.Tables
+
+	if dump.IsJobSucceeded(job) {
+		restore.Status.Duration = result.Duration()
+		message := fmt.Sprintf("Restored %d tables from %s", len(result.Tables), restore.Spec.Source.Archive)
+		r.setProgressing(restore, corev1.ConditionFalse, reasonSucceeded, fmt.Sprintf("Job %s has finished", job.Name))
+		r.setComplete(restore, corev1.ConditionTrue, reasonSucceeded, message)
+		r.recorder.Event(restore, corev1.EventTypeNormal, reasonSucceeded, message)
+		return nil
+	}
+
+	reason := result.Reason
+	if reason == "" {
+		reason = reasonRestoreFailed
+	}
+	message := result.Error
+	if message == "" {
+		message = fmt.Sprintf("Job %s failed", job.Name)
+	}
+	r.setProgressing(restore, corev1.ConditionFalse, reason, fmt.Sprintf("Job %s has finished", job.Name))
+	r.setComplete(restore, corev1.ConditionFalse, reason, message)
+	r.recorder.Event(restore, corev1.EventTypeWarning, reason, message)
+	return nil
+}
+
+// reconcileRestore ensures the restore Job exists for the given RethinkDBRestore and records its progress.
+func (r *ReconcileRethinkDBRestore) reconcileRestore(restore *rethinkdbv1alpha1.RethinkDBRestore) (reconcile.Result, error) {
+	if invalid := validateRestore(restore); invalid != nil {
+		r.setProgressing(restore, corev1.ConditionFalse, reasonInvalidSpec, invalid.Error())
+		return reconcile.Result{}, nil
+	}
+
+	job := &batchv1.Job{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: restore.Name, Namespace: restore.Namespace}, job)
+	if err != nil && errors.IsNotFound(err) {
+		if restore.Status.JobName != "" {
+			// The Job was removed before it finished, it is not recreated as the archive may be partially restored.
+			message := fmt.Sprintf("Job %s was removed before it finished", restore.Status.JobName)
+			r.setProgressing(restore, corev1.ConditionFalse, reasonJobNotFound, message)
+			r.setComplete(restore, corev1.ConditionFalse, reasonJobNotFound, message)
+			return reconcile.Result{}, nil
+		}
+		return r.createJob(restore)
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !metav1.IsControlledBy(job, restore) {
+		r.setProgressing(restore, corev1.ConditionFalse, reasonInvalidSpec, fmt.Sprintf("Job %s is not owned by the restore", job.Name))
+		return reconcile.Result{}, nil
+	}
+
+	restore.Status.JobName = job.Name
+	restore.Status.StartTime = job.Status.StartTime
+	if dump.IsJobSucceeded(job) || dump.IsJobFailed(job) {
+		return reconcile.Result{}, r.finishRestore(restore, job)
+	}
+
+	if job.Status.Active > 0 {
+		r.setProgressing(restore, corev1.ConditionTrue, reasonRunning, fmt.Sprintf("Job %s is running", job.Name))
+	} else {
+		r.setProgressing(restore, corev1.ConditionTrue, reasonPending, fmt.Sprintf("Job %s is pending", job.Name))
+	}
+	return reconcile.Result{}, nil
+}
+
+// setComplete sets the outcome of the given RethinkDBRestore with the given status, reason and message.
+func (r *ReconcileRethinkDBRestore) setComplete(restore *rethinkdbv1alpha1.RethinkDBRestore, status corev1.ConditionStatus, reason string, message string) {
+	log.Info("restore finished", "namespace", restore.Namespace, "name", restore.Name, "reason", reason)
+	rethinkdbv1alpha1.SetCondition(&restore.Status.Conditions, rethinkdbv1alpha1.RestoreConditionComplete, status, reason, message)
+}
+
+// setProgressing sets the progress of the given RethinkDBRestore with the given status, reason and message.
+func (r *ReconcileRethinkDBRestore) setProgressing(restore *rethinkdbv1alpha1.RethinkDBRestore, status corev1.ConditionStatus, reason string, message string) {
+	rethinkdbv1alpha1.SetCondition(&restore.Status.Conditions, rethinkdbv1alpha1.RestoreConditionProgressing, status, reason, message)
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbrestore
+
+import (
+	"errors"
+	"fmt"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/dump"
+)
+
+const (
+	// maxShards is the maximum number of shards of a RethinkDB table.
+	maxShards = 64
+
+	// restoreLabelKey is the label on the Job and Pods of a RethinkDBRestore with the name of the restore.
+	restoreLabelKey = "restore"
+)
+
+// defaultLabels returns the default set of labels for the Job of the given RethinkDBRestore.
+func defaultLabels(restore *rethinkdbv1alpha1.RethinkDBRestore) map[string]string {
+	return map[string]string{
+		rethinkdbcluster.RethinkDBAppKey: rethinkdbcluster.RethinkDBApp,
+		restoreLabelKey:                  restore.Name,
+	}
+}
+
+// isRestoreFinished returns true if the outcome of the given RethinkDBRestore has been recorded.
+func isRestoreFinished(restore *rethinkdbv1alpha1.RethinkDBRestore) bool {
+	return rethinkdbv1alpha1.FindCondition(restore.Status.Conditions, rethinkdbv1alpha1.RestoreConditionComplete) != nil
+}
+
+// validateRestore returns an error if the spec of the given RethinkDBRestore can not be run.
+func validateRestore(restore *rethinkdbv1alpha1.RethinkDBRestore) error {
+	if err := util.ValidateClusterReference(restore); err != nil {
+		return err
+	}
+	if restore.Spec.Source.Archive == "" {
+		return errors.New("source archive is required")
+	}
+	if shards := restore.Spec.Shards; shards != nil && (*shards < 1 || *shards > maxShards) {
+		return fmt.Errorf("shards must be between 1 and %d", maxShards)
+	}
+	if replicas := restore.Spec.Replicas; replicas != nil && *replicas < 1 {
+		return errors.New("replicas must be at least 1")
+	}
+
+	if err := dump.ValidateTables(restore.Spec.Tables); err != nil {
+		return err
+	}
+	return dump.ValidateArchiveStorage(&restore.Spec.Source.Storage)
+}
@@ -0,0 +1,442 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbtable
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rethinkdbtable")
+
+const (
+	// clusterRequeueDelay is the delay before checking whether the cluster and the database are available again.
+	clusterRequeueDelay = time.Second * 10
+
+	// statusRequeueDelay is the delay before checking the table_status system table again while replicas are not ready.
+	statusRequeueDelay = time.Second * 15
+
+	// tableFinalizer is the finalizer that applies the retention policy before the RethinkDBTable is removed.
+	tableFinalizer = "rethinkdb.com/table"
+)
+
+// Reasons for the events recorded and the conditions set on a RethinkDBTable.
+const (
+	reasonClusterNotFound    = "ClusterNotFound"
+	reasonClusterNotReady    = "ClusterNotReady"
+	reasonCreated            = "Created"
+	reasonDatabaseNotFound   = "DatabaseNotFound"
+	reasonDeleted            = "Deleted"
+	reasonIndexCreated       = "IndexCreated"
+	reasonIndexDropped       = "IndexDropped"
+	reasonInvalidSpec        = "InvalidSpec"
+	reasonPrimaryKeyMismatch = "PrimaryKeyMismatch"
+	reasonReconcileFailed    = "ReconcileFailed"
+	reasonReconfigured       = "Reconfigured"
+	reasonReplicasNotReady   = "ReplicasNotReady"
+	reasonRetained           = "Retained"
+	reasonSettingsUpdated    = "SettingsUpdated"
+	reasonSynced             = "Synced"
+)
+
+// Add creates a new RethinkDBTable Controller and adds it to the Manager. The Manager will set fields on the
+// Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRethinkDBTable{
+		client:         mgr.GetClient(),
+		scheme:         mgr.GetScheme(),
+		recorder:       mgr.GetRecorder("rethinkdbtable-controller"),
+		newAdminClient: admin.NewClientForCluster,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rethinkdbtable-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RethinkDBTable
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBTable{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBDatabases and requeue the RethinkDBTables in the database, so the tables are
+	// created as soon as the database is.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBDatabase{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: requestsForDatabase(mgr.GetClient()),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBClusters and requeue the RethinkDBTables in the cluster, so the tables are
+	// created once the cluster is available and their status follows the status of the cluster.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBCluster{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: util.RequestsForCluster(mgr.GetClient(), &rethinkdbv1alpha1.RethinkDBTableList{}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRethinkDBTable{}
+
+// ReconcileRethinkDBTable reconciles a RethinkDBTable object
+type ReconcileRethinkDBTable struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+
+	// newAdminClient opens admin connections to the RethinkDB cluster.
+	newAdminClient admin.ClientFunc
+}
+
+// Reconcile compares the table_config and index_list of the table in the RethinkDB cluster to the desired state for
+// the given RethinkDBTable request, and reports the readiness of the table from table_status.
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileRethinkDBTable) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("namespace", request.Namespace, "name", request.Name)
+	reqLogger.Info("reconciling RethinkDBTable")
+
+	// Fetch the RethinkDBTable instance
+	table := &rethinkdbv1alpha1.RethinkDBTable{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, table)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// Apply the retention policy before the resource is removed
+	if table.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.finalizeTable(table)
+	}
+
+	if !util.ContainsString(table.Finalizers, tableFinalizer) {
+		reqLogger.Info("adding finalizer")
+		table.Finalizers = append(table.Finalizers, tableFinalizer)
+		return reconcile.Result{Requeue: true}, r.client.Update(context.TODO(), table)
+	}
+
+	// Keep a copy of the observed status, the status is only updated at the end of the reconcile if it has changed.
+	observed := table.Status.DeepCopy()
+
+	result, err := r.reconcileTable(table)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile table")
+		rethinkdbv1alpha1.SetCondition(&table.Status.Conditions, rethinkdbv1alpha1.TableConditionReady, corev1.ConditionFalse, reasonReconcileFailed, err.Error())
+		r.recorder.Eventf(table, corev1.EventTypeWarning, reasonReconcileFailed, "Unable to reconcile table: %v", err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(observed, &table.Status) {
+		if updateErr := r.client.Status().Update(context.TODO(), table); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
+
+// dropTable drops the table of the given RethinkDBTable from the given cluster.
+func (r *ReconcileRethinkDBTable) dropTable(table *rethinkdbv1alpha1.RethinkDBTable, cluster *rethinkdbv1alpha1.RethinkDBCluster) error {
+	adminClient, err := r.newAdminClient(r.client, cluster)
+	if err != nil {
+		return err
+	}
+	defer adminClient.Close()
+
+	tables, err := adminClient.TableConfig()
+	if err != nil {
+		return err
+	}
+	if findTable(tables, table.Spec.Database, table.Status.Name) == nil {
+		return nil
+	}
+
+	log.Info("dropping table", "database", table.Spec.Database, "table", table.Status.Name)
+	if err = adminClient.DropTable(table.Spec.Database, table.Status.Name); err != nil {
+		return err
+	}
+	r.recorder.Eventf(table, corev1.EventTypeNormal, reasonDeleted, "Dropped table %s.%s", table.Spec.Database, table.Status.Name)
+	return nil
+}
+
+// finalizeTable applies the retention policy of the given RethinkDBTable and removes the finalizer. The table and
+// its data are only dropped with the Delete retention policy, and are left in place when the cluster no longer exists.
+func (r *ReconcileRethinkDBTable) finalizeTable(table *rethinkdbv1alpha1.RethinkDBTable) error {
+	if !util.ContainsString(table.Finalizers, tableFinalizer) {
+		return nil
+	}
+
+	if table.Status.Name != "" {
+		if table.Spec.RetentionPolicy == rethinkdbv1alpha1.RetentionPolicyDelete {
+			cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+			err := r.client.Get(context.TODO(), util.ClusterName(table), cluster)
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+
+			if err == nil && cluster.DeletionTimestamp == nil {
+				if err = r.dropTable(table, cluster); err != nil {
+					return err
+				}
+			}
+		} else {
+			r.recorder.Eventf(table, corev1.EventTypeNormal, reasonRetained, "Retained table %s.%s", table.Spec.Database, table.Status.Name)
+		}
+	}
+
+	log.Info("removing finalizer", "namespace", table.Namespace, "name", table.Name)
+	table.Finalizers = util.RemoveString(table.Finalizers, tableFinalizer)
+	return r.client.Update(context.TODO(), table)
+}
+
+// reconcileIndexes creates the secondary indexes of the given RethinkDBTable that are missing from the index_list of
+// the table, and drops the indexes that are no longer in the spec. Indexes are matched by name only.
+func (r *ReconcileRethinkDBTable) reconcileIndexes(table *rethinkdbv1alpha1.RethinkDBTable, adminClient admin.Client) error {
+	db := table.Spec.Database
+	name := tableName(table)
+
+	current, err := adminClient.IndexList(db, name)
+	if err != nil {
+		return err
+	}
+
+	desired := []string{}
+	for _, index := range table.Spec.Indexes {
+		desired = append(desired, index.Name)
+		if util.ContainsString(current, index.Name) {
+			continue
+		}
+
+		log.Info("creating index", "database", db, "table", name, "index", index.Name)
+		if err = adminClient.CreateIndex(db, name, index.Name, indexOptions(index)); err != nil {
+			return err
+		}
+		r.recorder.Eventf(table, corev1.EventTypeNormal, reasonIndexCreated, "Created index %s on table %s.%s", index.Name, db, name)
+	}
+
+	for _, index := range current {
+		if util.ContainsString(desired, index) {
+			continue
+		}
+
+		log.Info("dropping index", "database", db, "table", name, "index", index)
+		if err = adminClient.DropIndex(db, name, index); err != nil {
+			return err
+		}
+		r.recorder.Eventf(table, corev1.EventTypeNormal, reasonIndexDropped, "Dropped index %s from table %s.%s", index, db, name)
+	}
+	return nil
+}
+
+// reconcileTable ensures the table for the given RethinkDBTable exists in the referenced cluster with the
+// configuration and indexes from the spec, and updates the status from the table_status system table.
+func (r *ReconcileRethinkDBTable) reconcileTable(table *rethinkdbv1alpha1.RethinkDBTable) (reconcile.Result, error) {
+	if invalid := validateTable(table); invalid != nil {
+		r.setNotReady(table, reasonInvalidSpec, invalid.Error())
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), util.ClusterName(table), cluster)
+	if err != nil && errors.IsNotFound(err) {
+		// The cluster watch requeues the request once the cluster is created.
+		r.setNotReady(table, reasonClusterNotFound, fmt.Sprintf("RethinkDBCluster %s not found", util.ClusterName(table)))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !rethinkdbv1alpha1.IsConditionTrue(cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable) {
+		r.setNotReady(table, reasonClusterNotReady, fmt.Sprintf("RethinkDBCluster %s is not available", util.ClusterName(table)))
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	}
+
+	adminClient, err := r.newAdminClient(r.client, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer adminClient.Close()
+
+	db := table.Spec.Database
+	dbs, err := adminClient.DBList()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !util.ContainsString(dbs, db) {
+		// The database may be created outside of the operator, so keep checking.
+		r.setNotReady(table, reasonDatabaseNotFound, fmt.Sprintf("Database %s not found", db))
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	}
+
+	name := tableName(table)
+	tables, err := adminClient.TableConfig()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	config := findTable(tables, db, name)
+	if config == nil {
+		log.Info("creating table", "database", db, "table", name)
+		err = adminClient.CreateTable(db, name, admin.TableOptions{
+			PrimaryKey: primaryKey(table),
+			Durability: table.Spec.Durability,
+			Shards:     int(shards(table)),
+			Replicas:   int(replicas(table)),
+		})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(table, corev1.EventTypeNormal, reasonCreated, "Created table %s.%s", db, name)
+	} else {
+		if config.PrimaryKey != primaryKey(table) {
+			r.setNotReady(table, reasonPrimaryKeyMismatch, fmt.Sprintf("Table %s.%s has primary key %s, the primary key of a table can not be changed",
+				db, name, config.PrimaryKey))
+			return reconcile.Result{}, nil
+		}
+
+		if err = r.reconcileTableConfig(table, config, adminClient); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	table.Status.Name = name
+
+	if err = r.reconcileIndexes(table, adminClient); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return r.updateTableStatus(table, adminClient)
+}
+
+// reconcileTableConfig reconfigures the shards and replicas of the given table config and updates its durability and
+// write acknowledgements when they differ from the spec of the given RethinkDBTable.
+func (r *ReconcileRethinkDBTable) reconcileTableConfig(table *rethinkdbv1alpha1.RethinkDBTable, config *admin.TableConfig, adminClient admin.Client) error {
+	if int32(len(config.Shards)) != shards(table) || replicaCount(config) != replicas(table) {
+		log.Info("reconfiguring table", "database", config.DB, "table", config.Name, "shards", shards(table), "replicas", replicas(table))
+		if err := adminClient.ReconfigureTable(config.DB, config.Name, int(shards(table)), int(replicas(table))); err != nil {
+			return err
+		}
+		r.recorder.Eventf(table, corev1.EventTypeNormal, reasonReconfigured, "Reconfigured table %s.%s with %d shards and %d replicas",
+			config.DB, config.Name, shards(table), replicas(table))
+	}
+
+	durability := ""
+	if table.Spec.Durability != "" && table.Spec.Durability != config.Durability {
+		durability = table.Spec.Durability
+	}
+	var writeAcks interface{}
+	if table.Spec.WriteAcks != "" && table.Spec.WriteAcks != config.WriteAcks {
+		writeAcks = table.Spec.WriteAcks
+	}
+	if durability == "" && writeAcks == nil {
+		return nil
+	}
+
+	log.Info("updating table settings", "database", config.DB, "table", config.Name)
+	if err := adminClient.UpdateTableSettings(config.ID, durability, writeAcks); err != nil {
+		return err
+	}
+	r.recorder.Eventf(table, corev1.EventTypeNormal, reasonSettingsUpdated, "Updated durability and write acks of table %s.%s", config.DB, config.Name)
+	return nil
+}
+
+// setNotReady marks the given RethinkDBTable as not ready with the given reason and message.
+func (r *ReconcileRethinkDBTable) setNotReady(table *rethinkdbv1alpha1.RethinkDBTable, reason string, message string) {
+	log.Info("table not ready", "namespace", table.Namespace, "name", table.Name, "reason", reason)
+	rethinkdbv1alpha1.SetCondition(&table.Status.Conditions, rethinkdbv1alpha1.TableConditionReady, corev1.ConditionFalse, reason, message)
+}
+
+// updateTableStatus updates the status of the given RethinkDBTable from the table_config and table_status system
+// tables. The request is requeued until all replicas of the table are ready.
+func (r *ReconcileRethinkDBTable) updateTableStatus(table *rethinkdbv1alpha1.RethinkDBTable, adminClient admin.Client) (reconcile.Result, error) {
+	db := table.Spec.Database
+	name := tableName(table)
+
+	tables, err := adminClient.TableConfig()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	config := findTable(tables, db, name)
+	if config == nil {
+		return reconcile.Result{}, fmt.Errorf("table %s.%s not found in table_config", db, name)
+	}
+	table.Status.ID = config.ID
+	table.Status.Shards = int32(len(config.Shards))
+	table.Status.Replicas = replicaCount(config)
+
+	indexes, err := adminClient.IndexList(db, name)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	table.Status.Indexes = indexes
+
+	statuses, err := adminClient.TableStatus()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	status := findTableStatus(statuses, config.ID)
+	if status == nil {
+		return reconcile.Result{}, fmt.Errorf("table %s.%s not found in table_status", db, name)
+	}
+	table.Status.AllReplicasReady = status.Status.AllReplicasReady
+	table.Status.ReadyForReads = status.Status.ReadyForReads
+	table.Status.ReadyForWrites = status.Status.ReadyForWrites
+
+	if !status.Status.AllReplicasReady {
+		r.setNotReady(table, reasonReplicasNotReady, fmt.Sprintf("Not all replicas of table %s.%s are ready", db, name))
+		return reconcile.Result{RequeueAfter: statusRequeueDelay}, nil
+	}
+
+	rethinkdbv1alpha1.SetCondition(&table.Status.Conditions, rethinkdbv1alpha1.TableConditionReady, corev1.ConditionTrue, reasonSynced,
+		fmt.Sprintf("Table %s.%s matches the spec and all replicas are ready", db, name))
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbtable
+
+import (
+	"fmt"
+	"regexp"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+)
+
+const (
+	// defaultPrimaryKey is the name of the primary key field when none is given.
+	defaultPrimaryKey = "id"
+
+	// maxShards is the largest number of shards RethinkDB allows for a table.
+	maxShards = 64
+)
+
+// validName matches the names RethinkDB allows for databases, tables and indexes.
+var validName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// findTable returns the table with the given database and name from the given table configs, or nil if not found.
+func findTable(tables []admin.TableConfig, db string, name string) *admin.TableConfig {
+	for i := range tables {
+		if tables[i].DB == db && tables[i].Name == name {
+			return &tables[i]
+		}
+	}
+	return nil
+}
+
+// findTableStatus returns the status of the table with the given ID from the given table statuses, or nil if not
+// found.
+func findTableStatus(statuses []admin.TableStatus, id string) *admin.TableStatus {
+	for i := range statuses {
+		if statuses[i].ID == id {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// indexOptions returns the admin options to create the given index.
+func indexOptions(index rethinkdbv1alpha1.RethinkDBIndex) admin.IndexOptions {
+	return admin.IndexOptions{
+		Fields: index.Fields,
+		Multi:  index.Multi,
+		Geo:    index.Geo,
+	}
+}
+
+// primaryKey returns the name of the primary key field for the given RethinkDBTable.
+func primaryKey(table *rethinkdbv1alpha1.RethinkDBTable) string {
+	if table.Spec.PrimaryKey != "" {
+		return table.Spec.PrimaryKey
+	}
+	return defaultPrimaryKey
+}
+
+// replicaCount returns the number of replicas of each shard in the given table config.
+func replicaCount(config *admin.TableConfig) int32 {
+	if len(config.Shards) <= 0 {
+		return 0
+	}
+	return int32(len(config.Shards[0].Replicas))
+}
+
+// replicas returns the desired number of replicas of each shard for the given RethinkDBTable.
+func replicas(table *rethinkdbv1alpha1.RethinkDBTable) int32 {
+	if table.Spec.Replicas > 0 {
+		return table.Spec.Replicas
+	}
+	return 1
+}
+
+// shards returns the desired number of shards for the given RethinkDBTable.
+func shards(table *rethinkdbv1alpha1.RethinkDBTable) int32 {
+	if table.Spec.Shards > 0 {
+		return table.Spec.Shards
+	}
+	return 1
+}
+
+// tableName returns the name of the table for the given RethinkDBTable.
+func tableName(table *rethinkdbv1alpha1.RethinkDBTable) string {
+	if table.Spec.Name != "" {
+		return table.Spec.Name
+	}
+	return table.Name
+}
+
+// validateTable returns an error if the spec of the given RethinkDBTable can not be applied to the cluster.
+func validateTable(table *rethinkdbv1alpha1.RethinkDBTable) error {
+	if err := util.ValidateClusterReference(table); err != nil {
+		return err
+	}
+
+	db := table.Spec.Database
+	if db == "" {
+		return fmt.Errorf("database is required")
+	}
+	if db == admin.SystemDB {
+		return fmt.Errorf("database %s is managed by the cluster", admin.SystemDB)
+	}
+
+	name := tableName(table)
+	if !validName.MatchString(name) {
+		return fmt.Errorf("table name %s may only contain letters, numbers and underscores", name)
+	}
+	if table.Status.Name != "" && table.Status.Name != name {
+		return fmt.Errorf("table name can not be changed from %s to %s", table.Status.Name, name)
+	}
+
+	if shards(table) > maxShards {
+		return fmt.Errorf("a table can have at most %d shards", maxShards)
+	}
+
+	switch table.Spec.Durability {
+	case "", "hard", "soft":
+	default:
+		return fmt.Errorf("unknown durability %s", table.Spec.Durability)
+	}
+
+	switch table.Spec.WriteAcks {
+	case "", "majority", "single":
+	default:
+		return fmt.Errorf("unknown write acks %s", table.Spec.WriteAcks)
+	}
+
+	switch table.Spec.RetentionPolicy {
+	case "", rethinkdbv1alpha1.RetentionPolicyRetain, rethinkdbv1alpha1.RetentionPolicyDelete:
+	default:
+		return fmt.Errorf("unknown retention policy %s", table.Spec.RetentionPolicy)
+	}
+
+	indexes := map[string]bool{}
+	for _, index := range table.Spec.Indexes {
+		if !validName.MatchString(index.Name) {
+			return fmt.Errorf("index name %s may only contain letters, numbers and underscores", index.Name)
+		}
+		if indexes[index.Name] {
+			return fmt.Errorf("index %s is defined more than once", index.Name)
+		}
+		if index.Geo && len(index.Fields) > 1 {
+			return fmt.Errorf("geospatial index %s can not be a compound index", index.Name)
+		}
+		indexes[index.Name] = true
+	}
+	return nil
+}
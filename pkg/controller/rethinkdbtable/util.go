@@ -0,0 +1,54 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbtable
+
+import (
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// databaseName returns the name of the database managed by the given RethinkDBDatabase.
+func databaseName(db *rethinkdbv1alpha1.RethinkDBDatabase) string {
+	switch {
+	case db.Status.Name != "":
+		return db.Status.Name
+	case db.Spec.Name != "":
+		return db.Spec.Name
+	}
+	return db.Name
+}
+
+// requestsForDatabase returns a mapping from a RethinkDBDatabase to the requests for the RethinkDBTables in the
+// database.
+func requestsForDatabase(c client.Client) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		db, ok := obj.Object.(*rethinkdbv1alpha1.RethinkDBDatabase)
+		if !ok {
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, item := range util.List(c, &rethinkdbv1alpha1.RethinkDBTableList{}) {
+			table := item.(*rethinkdbv1alpha1.RethinkDBTable)
+			if util.ClusterName(table) == util.ClusterName(db) && table.Spec.Database == databaseName(db) {
+				requests = append(requests, util.RequestForObject(table))
+			}
+		}
+		return requests
+	}
+}
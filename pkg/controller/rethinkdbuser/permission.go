@@ -0,0 +1,130 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbuser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+)
+
+// permissionScope is the global, database or table scope of a set of permissions.
+type permissionScope struct {
+	Database string
+	Table    string
+}
+
+// String returns a description of the scope for log messages and events.
+func (s permissionScope) String() string {
+	switch {
+	case s.Database == "":
+		return "the cluster"
+	case s.Table == "":
+		return fmt.Sprintf("database %s", s.Database)
+	}
+	return fmt.Sprintf("table %s.%s", s.Database, s.Table)
+}
+
+// boolEqual returns true if the given optional values are both unset or have the same value.
+func boolEqual(a *bool, b *bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// currentPermissions returns the permissions of the user with the given name from the given permission documents,
+// by scope.
+func currentPermissions(perms []admin.Permission, name string) map[permissionScope]admin.PermissionSet {
+	current := map[permissionScope]admin.PermissionSet{}
+	for _, perm := range perms {
+		if perm.User == name {
+			current[permissionScope{Database: perm.Database, Table: perm.Table}] = perm.Permissions
+		}
+	}
+	return current
+}
+
+// desiredPermissions returns the permissions from the spec of the given RethinkDBUser, by scope. Scopes without any
+// permissions set are left out, so they are revoked.
+func desiredPermissions(user *rethinkdbv1alpha1.RethinkDBUser) map[permissionScope]admin.PermissionSet {
+	desired := map[permissionScope]admin.PermissionSet{}
+	for _, perm := range user.Spec.Permissions {
+		set := admin.PermissionSet{
+			Read:    perm.Read,
+			Write:   perm.Write,
+			Config:  perm.Config,
+			Connect: perm.Connect,
+		}
+		if set != (admin.PermissionSet{}) {
+			desired[permissionScope{Database: perm.Database, Table: perm.Table}] = set
+		}
+	}
+	return desired
+}
+
+// permissionSetString returns a description of the permissions that are set in the given PermissionSet.
+func permissionSetString(set admin.PermissionSet) string {
+	perms := []string{}
+	for _, perm := range []struct {
+		name  string
+		value *bool
+	}{{"read", set.Read}, {"write", set.Write}, {"config", set.Config}, {"connect", set.Connect}} {
+		if perm.value != nil {
+			perms = append(perms, fmt.Sprintf("%s=%t", perm.name, *perm.value))
+		}
+	}
+	return strings.Join(perms, ",")
+}
+
+// permissionSetsEqual returns true if the given PermissionSets set the same permissions.
+func permissionSetsEqual(a admin.PermissionSet, b admin.PermissionSet) bool {
+	return boolEqual(a.Read, b.Read) && boolEqual(a.Write, b.Write) && boolEqual(a.Config, b.Config) && boolEqual(a.Connect, b.Connect)
+}
+
+// validateUser returns an error if the spec of the given RethinkDBUser can not be applied to the cluster.
+func validateUser(user *rethinkdbv1alpha1.RethinkDBUser) error {
+	if err := util.ValidateClusterReference(user); err != nil {
+		return err
+	}
+
+	name := username(user)
+	if name == adminUsername {
+		return fmt.Errorf("user %s is managed by the cluster", adminUsername)
+	}
+	if user.Status.Username != "" && user.Status.Username != name {
+		return fmt.Errorf("username can not be changed from %s to %s", user.Status.Username, name)
+	}
+
+	scopes := map[permissionScope]bool{}
+	for _, perm := range user.Spec.Permissions {
+		scope := permissionScope{Database: perm.Database, Table: perm.Table}
+		if scope.Database == "" && scope.Table != "" {
+			return fmt.Errorf("permissions on table %s require a database", scope.Table)
+		}
+		if scope.Database != "" && perm.Connect != nil {
+			return errors.New("the connect permission can only be granted globally")
+		}
+		if scopes[scope] {
+			return fmt.Errorf("permissions on %s are set more than once", scope.String())
+		}
+		scopes[scope] = true
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbuser
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_rethinkdbuser")
+
+const (
+	// clusterRequeueDelay is the delay before checking whether the cluster is available again.
+	clusterRequeueDelay = time.Second * 10
+
+	// userFinalizer is the finalizer that deletes the user account from the cluster before the RethinkDBUser is removed.
+	userFinalizer = "rethinkdb.com/user"
+)
+
+// Reasons for the events recorded and the conditions set on a RethinkDBUser.
+const (
+	reasonClusterNotFound    = "ClusterNotFound"
+	reasonClusterNotReady    = "ClusterNotReady"
+	reasonCreated            = "Created"
+	reasonDeleted            = "Deleted"
+	reasonInvalidSpec        = "InvalidSpec"
+	reasonPasswordUpdated    = "PasswordUpdated"
+	reasonPermissionsGranted = "PermissionsGranted"
+	reasonPermissionsRevoked = "PermissionsRevoked"
+	reasonReconcileFailed    = "ReconcileFailed"
+	reasonSynced             = "Synced"
+)
+
+// Add creates a new RethinkDBUser Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileRethinkDBUser{
+		client:         mgr.GetClient(),
+		scheme:         mgr.GetScheme(),
+		recorder:       mgr.GetRecorder("rethinkdbuser-controller"),
+		newAdminClient: admin.NewClientForCluster,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("rethinkdbuser-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource RethinkDBUser
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBUser{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to password Secrets and requeue the RethinkDBUsers that use them. Existing password Secrets
+	// are not owned by the user, so the users are found by the name of their password Secret.
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: requestsForSecret(mgr.GetClient()),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to RethinkDBClusters and requeue the RethinkDBUsers in the cluster, so the users are created
+	// once the cluster is available.
+	err = c.Watch(&source.Kind{Type: &rethinkdbv1alpha1.RethinkDBCluster{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: util.RequestsForCluster(mgr.GetClient(), &rethinkdbv1alpha1.RethinkDBUserList{}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRethinkDBUser{}
+
+// ReconcileRethinkDBUser reconciles a RethinkDBUser object
+type ReconcileRethinkDBUser struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+
+	// newAdminClient opens admin connections to the RethinkDB cluster, it may be replaced with a fake for testing.
+	newAdminClient admin.ClientFunc
+}
+
+// Reconcile compares the user account and permissions in the RethinkDB cluster to the desired state for the given
+// RethinkDBUser request.
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileRethinkDBUser) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("namespace", request.Namespace, "name", request.Name)
+	reqLogger.Info("reconciling RethinkDBUser")
+
+	// Fetch the RethinkDBUser instance
+	user := &rethinkdbv1alpha1.RethinkDBUser{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, user)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// Delete the user account from the cluster before the resource is removed
+	if user.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.finalizeUser(user)
+	}
+
+	if !util.ContainsString(user.Finalizers, userFinalizer) {
+		reqLogger.Info("adding finalizer")
+		user.Finalizers = append(user.Finalizers, userFinalizer)
+		return reconcile.Result{Requeue: true}, r.client.Update(context.TODO(), user)
+	}
+
+	// Keep a copy of the observed status, the status is only updated at the end of the reconcile if it has changed.
+	observed := user.Status.DeepCopy()
+
+	result, err := r.reconcileUser(user)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile user")
+		rethinkdbv1alpha1.SetCondition(&user.Status.Conditions, rethinkdbv1alpha1.UserConditionReady, corev1.ConditionFalse, reasonReconcileFailed, err.Error())
+		r.recorder.Eventf(user, corev1.EventTypeWarning, reasonReconcileFailed, "Unable to reconcile user: %v", err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(observed, &user.Status) {
+		if updateErr := r.client.Status().Update(context.TODO(), user); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
+
+// deleteUser deletes the user account of the given RethinkDBUser from the given cluster, which revokes all of its
+// permissions.
+func (r *ReconcileRethinkDBUser) deleteUser(user *rethinkdbv1alpha1.RethinkDBUser, cluster *rethinkdbv1alpha1.RethinkDBCluster) error {
+	adminClient, err := r.newAdminClient(r.client, cluster)
+	if err != nil {
+		return err
+	}
+	defer adminClient.Close()
+
+	users, err := adminClient.Users()
+	if err != nil {
+		return err
+	}
+	if findUser(users, user.Status.Username) == nil {
+		return nil
+	}
+
+	log.Info("deleting user", "username", user.Status.Username)
+	if err = adminClient.DeleteUser(user.Status.Username); err != nil {
+		return err
+	}
+	r.recorder.Eventf(user, corev1.EventTypeNormal, reasonDeleted, "Deleted user %s", user.Status.Username)
+	return nil
+}
+
+// finalizeUser deletes the user account of the given RethinkDBUser from the cluster and removes the finalizer. The
+// account is left in place when the cluster no longer exists.
+func (r *ReconcileRethinkDBUser) finalizeUser(user *rethinkdbv1alpha1.RethinkDBUser) error {
+	if !util.ContainsString(user.Finalizers, userFinalizer) {
+		return nil
+	}
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), util.ClusterName(user), cluster)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && cluster.DeletionTimestamp == nil && user.Status.Username != "" {
+		if err = r.deleteUser(user, cluster); err != nil {
+			return err
+		}
+	}
+
+	log.Info("removing finalizer", "namespace", user.Namespace, "name", user.Name)
+	user.Finalizers = util.RemoveString(user.Finalizers, userFinalizer)
+	return r.client.Update(context.TODO(), user)
+}
+
+// reconcilePasswordSecret ensures the password Secret for the given RethinkDBUser is present. A Secret with a random
+// password is created when it does not exist. The Secret is returned upon success.
+func (r *ReconcileRethinkDBUser) reconcilePasswordSecret(user *rethinkdbv1alpha1.RethinkDBUser) (*corev1.Secret, error) {
+	name := passwordSecretName(user)
+	found := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: user.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("creating new secret", "secret", name)
+		secret, err := newPasswordSecret(user)
+		if err != nil {
+			return nil, err
+		}
+
+		// Set RethinkDBUser instance as the owner and controller, so the Secret is removed with the resource
+		if err = controllerutil.SetControllerReference(user, secret, r.scheme); err != nil {
+			return nil, err
+		}
+
+		if err = r.client.Create(context.TODO(), secret); err != nil {
+			return nil, err
+		}
+
+		r.recorder.Eventf(user, corev1.EventTypeNormal, reasonCreated, "Created secret %s", name)
+		return secret, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(found.Data[rethinkdbcluster.RethinkDBPasswordKey]) <= 0 {
+		return nil, fmt.Errorf("secret %s has no %s key", name, rethinkdbcluster.RethinkDBPasswordKey)
+	}
+	return found, nil
+}
+
+// reconcilePermissions grants the permissions of the given RethinkDBUser that differ from the current permissions of
+// the user account, and revokes the permissions that are no longer in the spec.
+func (r *ReconcileRethinkDBUser) reconcilePermissions(user *rethinkdbv1alpha1.RethinkDBUser, adminClient admin.Client) error {
+	perms, err := adminClient.Permissions()
+	if err != nil {
+		return err
+	}
+
+	name := username(user)
+	current := currentPermissions(perms, name)
+	desired := desiredPermissions(user)
+
+	for scope, set := range desired {
+		if existing, ok := current[scope]; ok && permissionSetsEqual(existing, set) {
+			continue
+		}
+
+		log.Info("granting permissions", "username", name, "scope", scope.String())
+		if err = adminClient.GrantPermissions(name, scope.Database, scope.Table, set); err != nil {
+			return err
+		}
+		r.recorder.Eventf(user, corev1.EventTypeNormal, reasonPermissionsGranted, "Granted %s permissions on %s to user %s",
+			permissionSetString(set), scope.String(), name)
+	}
+
+	for scope := range current {
+		if _, ok := desired[scope]; ok {
+			continue
+		}
+
+		log.Info("revoking permissions", "username", name, "scope", scope.String())
+		if err = adminClient.GrantPermissions(name, scope.Database, scope.Table, admin.PermissionSet{}); err != nil {
+			return err
+		}
+		r.recorder.Eventf(user, corev1.EventTypeNormal, reasonPermissionsRevoked, "Revoked permissions on %s from user %s",
+			scope.String(), name)
+	}
+	return nil
+}
+
+// reconcileUser ensures the user account for the given RethinkDBUser exists in the referenced cluster with the
+// password from the password Secret and the permissions from the spec.
+func (r *ReconcileRethinkDBUser) reconcileUser(user *rethinkdbv1alpha1.RethinkDBUser) (reconcile.Result, error) {
+	if invalid := validateUser(user); invalid != nil {
+		r.setNotReady(user, reasonInvalidSpec, invalid.Error())
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{}
+	err := r.client.Get(context.TODO(), util.ClusterName(user), cluster)
+	if err != nil && errors.IsNotFound(err) {
+		// The cluster watch requeues the request once the cluster is created.
+		r.setNotReady(user, reasonClusterNotFound, fmt.Sprintf("RethinkDBCluster %s not found", util.ClusterName(user)))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !rethinkdbv1alpha1.IsConditionTrue(cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable) {
+		r.setNotReady(user, reasonClusterNotReady, fmt.Sprintf("RethinkDBCluster %s is not available", util.ClusterName(user)))
+		return reconcile.Result{RequeueAfter: clusterRequeueDelay}, nil
+	}
+
+	secret, err := r.reconcilePasswordSecret(user)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	password := string(secret.Data[rethinkdbcluster.RethinkDBPasswordKey])
+
+	adminClient, err := r.newAdminClient(r.client, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer adminClient.Close()
+
+	users, err := adminClient.Users()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	name := username(user)
+	if findUser(users, name) == nil {
+		log.Info("creating user", "username", name)
+		if err = adminClient.CreateUser(name, password); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(user, corev1.EventTypeNormal, reasonCreated, "Created user %s", name)
+	} else if user.Status.PasswordSecretVersion != secret.ResourceVersion {
+		log.Info("updating user password", "username", name)
+		if err = adminClient.UpdateUserPassword(name, password); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.recorder.Eventf(user, corev1.EventTypeNormal, reasonPasswordUpdated, "Updated password of user %s from secret %s", name, secret.Name)
+	}
+	user.Status.Username = name
+	user.Status.PasswordSecretVersion = secret.ResourceVersion
+
+	if err = r.reconcilePermissions(user, adminClient); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	rethinkdbv1alpha1.SetCondition(&user.Status.Conditions, rethinkdbv1alpha1.UserConditionReady, corev1.ConditionTrue, reasonSynced,
+		fmt.Sprintf("User %s and its permissions match the spec", name))
+	return reconcile.Result{}, nil
+}
+
+// setNotReady marks the given RethinkDBUser as not ready with the given reason and message.
+func (r *ReconcileRethinkDBUser) setNotReady(user *rethinkdbv1alpha1.RethinkDBUser, reason string, message string) {
+	log.Info("user not ready", "namespace", user.Namespace, "name", user.Name, "reason", reason)
+	rethinkdbv1alpha1.SetCondition(&user.Status.Conditions, rethinkdbv1alpha1.UserConditionReady, corev1.ConditionFalse, reason, message)
+}
@@ -0,0 +1,139 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbuser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis"
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// eventReasons returns the number of events recorded by the given recorder by reason, and drains the recorded
+// events.
+func eventReasons(recorder *record.FakeRecorder) map[string]int {
+	reasons := map[string]int{}
+	for {
+		select {
+		case event := <-recorder.Events:
+			reasons[strings.SplitN(event, " ", 3)[1]]++
+		default:
+			return reasons
+		}
+	}
+}
+
+// newBool returns a pointer to the given value.
+func newBool(value bool) *bool {
+	return &value
+}
+
+func TestReconcilePermissions(t *testing.T) {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatalf("unable to add the API types to the scheme: %v", err)
+	}
+
+	cluster := &rethinkdbv1alpha1.RethinkDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+	}
+	rethinkdbv1alpha1.SetCondition(&cluster.Status.Conditions, rethinkdbv1alpha1.ClusterConditionAvailable, corev1.ConditionTrue, "ServersReady", "")
+
+	user := &rethinkdbv1alpha1.RethinkDBUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Finalizers: []string{userFinalizer}},
+		Spec: rethinkdbv1alpha1.RethinkDBUserSpec{
+			Cluster: rethinkdbv1alpha1.RethinkDBClusterReference{Name: cluster.Name},
+			Permissions: []rethinkdbv1alpha1.RethinkDBPermission{
+				{Connect: newBool(true)},
+				{Database: "app", Read: newBool(true), Write: newBool(true)},
+				{Database: "app", Table: "users", Read: newBool(true)},
+			},
+		},
+	}
+
+	// The global permissions match the spec, the database permissions lack write and the permissions on the logs
+	// table are no longer in the spec. The permissions of the other user are not managed by the resource.
+	ac := &fake.Client{
+		UserDocs: []admin.User{{ID: "app", Password: true}, {ID: "other", Password: true}},
+		PermDocs: []admin.Permission{
+			{User: "app", Permissions: admin.PermissionSet{Connect: newBool(true)}},
+			{User: "app", Database: "app", Permissions: admin.PermissionSet{Read: newBool(true)}},
+			{User: "app", Database: "app", Table: "logs", Permissions: admin.PermissionSet{Write: newBool(true)}},
+			{User: "other", Database: "app", Permissions: admin.PermissionSet{Read: newBool(true)}},
+		},
+	}
+	recorder := record.NewFakeRecorder(100)
+	r := &ReconcileRethinkDBUser{
+		client:         fakeclient.NewFakeClient(cluster, user),
+		scheme:         scheme.Scheme,
+		recorder:       recorder,
+		newAdminClient: fake.NewClientFunc(ac),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: user.Name, Namespace: user.Namespace}}
+
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	want := desiredPermissions(user)
+	got := currentPermissions(ac.PermDocs, "app")
+	if len(got) != len(want) {
+		t.Errorf("permissions = %v, want %v", got, want)
+	}
+	for scope, set := range want {
+		if !permissionSetsEqual(got[scope], set) {
+			t.Errorf("permissions on %s = %s, want %s", scope, permissionSetString(got[scope]), permissionSetString(set))
+		}
+	}
+	if other := currentPermissions(ac.PermDocs, "other"); len(other) != 1 {
+		t.Errorf("permissions of the other user = %v, want them unchanged", other)
+	}
+
+	// Only the database and the users table are granted and the logs table is revoked, the unchanged global
+	// permissions are left as is.
+	reasons := eventReasons(recorder)
+	if reasons[reasonPermissionsGranted] != 2 || reasons[reasonPermissionsRevoked] != 1 {
+		t.Errorf("granted permissions %d times and revoked %d times, want 2 and 1",
+			reasons[reasonPermissionsGranted], reasons[reasonPermissionsRevoked])
+	}
+	found := &rethinkdbv1alpha1.RethinkDBUser{}
+	if err := r.client.Get(context.TODO(), request.NamespacedName, found); err != nil {
+		t.Fatalf("unable to get user: %v", err)
+	}
+	if !rethinkdbv1alpha1.IsConditionTrue(found.Status.Conditions, rethinkdbv1alpha1.UserConditionReady) {
+		t.Errorf("conditions = %+v, want %s", found.Status.Conditions, rethinkdbv1alpha1.UserConditionReady)
+	}
+
+	// The permissions match the spec, so nothing is granted or revoked again.
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	reasons = eventReasons(recorder)
+	if reasons[reasonPermissionsGranted] != 0 || reasons[reasonPermissionsRevoked] != 0 {
+		t.Errorf("granted permissions %d times and revoked %d times after the permissions matched the spec, want 0",
+			reasons[reasonPermissionsGranted], reasons[reasonPermissionsRevoked])
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbuser
+
+import (
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/sethvargo/go-password/password"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newPasswordSecret creates a new Opaque secret with a random password for the given RethinkDBUser.
+func newPasswordSecret(user *rethinkdbv1alpha1.RethinkDBUser) (*corev1.Secret, error) {
+	psswd, err := password.Generate(16, 4, 0, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      passwordSecretName(user),
+			Namespace: user.Namespace,
+			Labels:    labelsForUser(user),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			rethinkdbcluster.RethinkDBUsernameKey: []byte(username(user)),
+			rethinkdbcluster.RethinkDBPasswordKey: []byte(psswd),
+		},
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rethinkdbuser
+
+import (
+	"fmt"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/rethinkdbcluster"
+	"github.com/jmckind/rethinkdb-operator/pkg/controller/util"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// adminUsername is the name of the admin user account, which is managed by the cluster.
+	adminUsername = "admin"
+
+	// userLabel is the label with the name of the RethinkDBUser that owns a Secret.
+	userLabel = "user"
+)
+
+// findUser returns the user with the given name from the given users, or nil if not found.
+func findUser(users []admin.User, name string) *admin.User {
+	for i := range users {
+		if users[i].ID == name {
+			return &users[i]
+		}
+	}
+	return nil
+}
+
+// labelsForUser returns the labels for the password Secret of the given RethinkDBUser.
+func labelsForUser(user *rethinkdbv1alpha1.RethinkDBUser) map[string]string {
+	labels := map[string]string{
+		rethinkdbcluster.RethinkDBAppKey: rethinkdbcluster.RethinkDBApp,
+		userLabel:                        user.Name,
+	}
+	for key, val := range user.ObjectMeta.Labels {
+		labels[key] = val
+	}
+	return labels
+}
+
+// passwordSecretName returns the name of the Secret with the password for the given RethinkDBUser.
+func passwordSecretName(user *rethinkdbv1alpha1.RethinkDBUser) string {
+	if user.Spec.PasswordSecret != "" {
+		return user.Spec.PasswordSecret
+	}
+	return fmt.Sprintf("%s-%s", user.Name, rethinkdbcluster.RethinkDBPasswordKey)
+}
+
+// requestsForSecret returns a mapping from a Secret to the requests for the RethinkDBUsers that use the Secret for
+// their password.
+func requestsForSecret(c client.Client) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		requests := []reconcile.Request{}
+		for _, item := range util.List(c, &rethinkdbv1alpha1.RethinkDBUserList{}) {
+			user := item.(*rethinkdbv1alpha1.RethinkDBUser)
+			if user.Namespace == obj.Meta.GetNamespace() && passwordSecretName(user) == obj.Meta.GetName() {
+				requests = append(requests, util.RequestForObject(user))
+			}
+		}
+		return requests
+	}
+}
+
+// username returns the name of the user account for the given RethinkDBUser.
+func username(user *rethinkdbv1alpha1.RethinkDBUser) string {
+	if user.Spec.Username != "" {
+		return user.Spec.Username
+	}
+	return user.Name
+}
@@ -0,0 +1,130 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package util holds the helpers shared by the controllers of the resources that reference a RethinkDBCluster.
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("controller_util")
+
+// ClusterObject is a resource that references a RethinkDBCluster.
+type ClusterObject interface {
+	metav1.Object
+	runtime.Object
+
+	// ClusterReference returns the reference to the RethinkDBCluster of the resource.
+	ClusterReference() rethinkdbv1alpha1.RethinkDBClusterReference
+}
+
+// ClusterName returns the namespaced name of the RethinkDBCluster referenced by the given object.
+func ClusterName(obj ClusterObject) types.NamespacedName {
+	return obj.ClusterReference().NamespacedName(obj.GetNamespace())
+}
+
+// ContainsString returns true if the given slice contains the given string.
+func ContainsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the objects of the given list type visible to the given client. The given list is not modified, so
+// it may be shared between calls.
+func List(c client.Client, list runtime.Object) []ClusterObject {
+	found := list.DeepCopyObject()
+	if err := c.List(context.TODO(), &client.ListOptions{}, found); err != nil {
+		log.Error(err, "unable to list resources", "type", fmt.Sprintf("%T", found))
+		return nil
+	}
+
+	items, err := meta.ExtractList(found)
+	if err != nil {
+		log.Error(err, "unable to extract list items")
+		return nil
+	}
+
+	objs := []ClusterObject{}
+	for _, item := range items {
+		if obj, ok := item.(ClusterObject); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// RemoveString returns a copy of the given slice with all occurrences of the given string removed.
+func RemoveString(slice []string, s string) []string {
+	result := []string{}
+	for _, item := range slice {
+		if item != s {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// RequestForObject returns the reconcile request for the given object.
+func RequestForObject(obj metav1.Object) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}}
+}
+
+// RequestsForCluster returns a mapping from a RethinkDBCluster to the requests for the objects of the given list
+// type that reference the cluster.
+func RequestsForCluster(c client.Client, list runtime.Object) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		requests := []reconcile.Request{}
+		for _, item := range List(c, list) {
+			cluster := ClusterName(item)
+			if cluster.Name == obj.Meta.GetName() && cluster.Namespace == obj.Meta.GetNamespace() {
+				requests = append(requests, RequestForObject(item))
+			}
+		}
+		return requests
+	}
+}
+
+// RequestsForReferencedCluster maps an object that references a RethinkDBCluster to the request for the cluster.
+func RequestsForReferencedCluster(obj handler.MapObject) []reconcile.Request {
+	item, ok := obj.Object.(ClusterObject)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: ClusterName(item)}}
+}
+
+// ValidateClusterReference returns an error if the given object does not name the RethinkDBCluster it references.
+func ValidateClusterReference(obj ClusterObject) error {
+	if obj.ClusterReference().Name == "" {
+		return errors.New("cluster name is required")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	rethinkdbv1alpha1 "github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestValidateClusterReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster string
+		valid   bool
+	}{
+		{"named", "rethinkdb", true},
+		{"unnamed", "", false},
+	}
+
+	for _, tt := range tests {
+		cc := &rethinkdbv1alpha1.RethinkDBClientCert{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "apps"},
+			Spec: rethinkdbv1alpha1.RethinkDBClientCertSpec{
+				Cluster: rethinkdbv1alpha1.RethinkDBClusterReference{Name: tt.cluster},
+			},
+		}
+
+		err := ValidateClusterReference(cc)
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+
+		want := types.NamespacedName{Name: tt.cluster, Namespace: "apps"}
+		if got := ClusterName(cc); got != want {
+			t.Errorf("%s: got cluster %s, want %s", tt.name, got, want)
+		}
+	}
+}
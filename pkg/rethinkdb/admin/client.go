@@ -0,0 +1,518 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides a client for administering a RethinkDB cluster through the system tables.
+package admin
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	rdb "gopkg.in/rethinkdb/rethinkdb-go.v5"
+	"gopkg.in/rethinkdb/rethinkdb-go.v5/ql2"
+)
+
+const (
+	// SystemDB is the name of the RethinkDB system database.
+	SystemDB = "rethinkdb"
+
+	// DefaultTimeout is the default timeout when connecting to a cluster.
+	DefaultTimeout = time.Second * 10
+
+	// WritesBlockedError is the error the write hook set by BlockWrites rejects every write with.
+	WritesBlockedError = "writes are blocked by the rethinkdb-operator while volume snapshots are taken"
+)
+
+// Client administers a RethinkDB cluster through the system tables.
+type Client interface {
+	// Close closes the connection to the cluster.
+	Close() error
+
+	// ServerStatus returns the documents from the server_status system table.
+	ServerStatus() ([]ServerStatus, error)
+
+	// RenameServer changes the name of the server with the given ID.
+	RenameServer(id string, name string) error
+
+	// TableConfig returns the documents from the table_config system table.
+	TableConfig() ([]TableConfig, error)
+
+	// UpdateTableShards updates the shard configuration for the table with the given ID.
+	UpdateTableShards(id string, shards []Shard) error
+
+	// TableStatus returns the documents from the table_status system table.
+	TableStatus() ([]TableStatus, error)
+
+	// CurrentIssues returns the documents from the current_issues system table.
+	CurrentIssues() ([]Issue, error)
+
+	// Stats returns the documents from the stats system table.
+	Stats() ([]Stats, error)
+
+	// Jobs returns the documents from the jobs system table.
+	Jobs() ([]Job, error)
+
+	// Users returns the documents from the users system table.
+	Users() ([]User, error)
+
+	// Permissions returns the documents from the permissions system table.
+	Permissions() ([]Permission, error)
+
+	// CreateUser creates a user account with the given name and password.
+	CreateUser(name string, password string) error
+
+	// UpdateUserPassword changes the password of the user account with the given name.
+	UpdateUserPassword(name string, password string) error
+
+	// DeleteUser deletes the user account with the given name, which also removes all of its permissions.
+	DeleteUser(name string) error
+
+	// GrantPermissions sets the permissions of the given user globally, on the given database, or on the given table
+	// of the database. Permissions that are not set in the given PermissionSet are removed at that scope.
+	GrantPermissions(user string, database string, table string, permissions PermissionSet) error
+
+	// DBList returns the names of the databases.
+	DBList() ([]string, error)
+
+	// CreateDB creates a database with the given name.
+	CreateDB(name string) error
+
+	// DropDB drops the database with the given name and all of its tables.
+	DropDB(name string) error
+
+	// TableList returns the names of the tables in the given database.
+	TableList(db string) ([]string, error)
+
+	// CreateTable creates a table with the given name and options in the given database.
+	CreateTable(db string, name string, opts TableOptions) error
+
+	// DropTable drops the table with the given name from the given database.
+	DropTable(db string, name string) error
+
+	// ReconfigureTable changes the number of shards and replicas of the given table.
+	ReconfigureTable(db string, name string, shards int, replicas int) error
+
+	// UpdateTableSettings updates the durability and write acknowledgements of the table with the given ID.
+	UpdateTableSettings(id string, durability string, writeAcks interface{}) error
+
+	// IndexList returns the names of the secondary indexes of the given table.
+	IndexList(db string, table string) ([]string, error)
+
+	// CreateIndex creates a secondary index with the given name and options on the given table.
+	CreateIndex(db string, table string, name string, opts IndexOptions) error
+
+	// DropIndex drops the secondary index with the given name from the given table.
+	DropIndex(db string, table string, name string) error
+
+	// Sync flushes the writes of the given table to disk.
+	Sync(db string, table string) error
+
+	// WriteHook returns the write hook of the given table, or nil if the table has no write hook.
+	WriteHook(db string, table string) (*WriteHook, error)
+
+	// SetWriteHook sets the write hook of the given table to the given serialized function returned by WriteHook,
+	// or removes the write hook when the function is nil.
+	SetWriteHook(db string, table string, function []byte) error
+
+	// BlockWrites sets a write hook on the given table that rejects every write with WritesBlockedError.
+	BlockWrites(db string, table string) error
+}
+
+// Config is the configuration for connecting to a RethinkDB cluster.
+type Config struct {
+	// Address is the host and driver port of the cluster.
+	Address string
+
+	// Username is the name of the user to connect as.
+	Username string
+
+	// Password is the password for the user.
+	Password string
+
+	// TLSConfig is the TLS configuration for the connection, or nil for a plaintext connection.
+	TLSConfig *tls.Config
+
+	// Timeout is the timeout when connecting to the cluster.
+	Timeout time.Duration
+}
+
+// sessionClient is the Client implementation backed by a rethinkdb-go session.
+type sessionClient struct {
+	session *rdb.Session
+}
+
+var _ Client = &sessionClient{}
+
+// NewClient opens a new connection to a RethinkDB cluster using the given configuration.
+func NewClient(cfg *Config) (Client, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	session, err := rdb.Connect(rdb.ConnectOpts{
+		Address:   cfg.Address,
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+		TLSConfig: cfg.TLSConfig,
+		Timeout:   timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sessionClient{session: session}, nil
+}
+
+// BlockWrites sets a write hook on the given table that rejects every write with WritesBlockedError.
+func (c *sessionClient) BlockWrites(db string, table string) error {
+	hook := func(ctx rdb.Term, oldVal rdb.Term, newVal rdb.Term) rdb.Term {
+		return rdb.Error(WritesBlockedError)
+	}
+	return c.setWriteHook(db, table, hook)
+}
+
+// Close closes the connection to the cluster.
+func (c *sessionClient) Close() error {
+	return c.session.Close()
+}
+
+// CreateDB creates a database with the given name.
+func (c *sessionClient) CreateDB(name string) error {
+	_, err := rdb.DBCreate(name).RunWrite(c.session)
+	return err
+}
+
+// CreateIndex creates a secondary index with the given name and options on the given table. An index on multiple
+// fields is created as a compound index.
+func (c *sessionClient) CreateIndex(db string, table string, name string, opts IndexOptions) error {
+	fields := opts.Fields
+	if len(fields) <= 0 {
+		fields = []string{name}
+	}
+	indexOpts := rdb.IndexCreateOpts{Multi: opts.Multi, Geo: opts.Geo}
+
+	term := rdb.DB(db).Table(table)
+	if len(fields) == 1 {
+		term = term.IndexCreateFunc(name, rdb.Row.Field(fields[0]), indexOpts)
+	} else {
+		compound := []interface{}{}
+		for _, field := range fields {
+			compound = append(compound, rdb.Row.Field(field))
+		}
+		term = term.IndexCreateFunc(name, compound, indexOpts)
+	}
+	_, err := term.RunWrite(c.session)
+	return err
+}
+
+// CreateTable creates a table with the given name and options in the given database.
+func (c *sessionClient) CreateTable(db string, name string, opts TableOptions) error {
+	createOpts := rdb.TableCreateOpts{}
+	if opts.PrimaryKey != "" {
+		createOpts.PrimaryKey = opts.PrimaryKey
+	}
+	if opts.Durability != "" {
+		createOpts.Durability = opts.Durability
+	}
+	if opts.Shards > 0 {
+		createOpts.Shards = opts.Shards
+	}
+	if opts.Replicas > 0 {
+		createOpts.Replicas = opts.Replicas
+	}
+	_, err := rdb.DB(db).TableCreate(name, createOpts).RunWrite(c.session)
+	return err
+}
+
+// CreateUser creates a user account with the given name and password.
+func (c *sessionClient) CreateUser(name string, password string) error {
+	_, err := rdb.DB(SystemDB).Table("users").Insert(map[string]interface{}{
+		"id":       name,
+		"password": password,
+	}).RunWrite(c.session)
+	return err
+}
+
+// CurrentIssues returns the documents from the current_issues system table.
+func (c *sessionClient) CurrentIssues() ([]Issue, error) {
+	issues := []Issue{}
+	err := c.list("current_issues", &issues)
+	return issues, err
+}
+
+// DBList returns the names of the databases.
+func (c *sessionClient) DBList() ([]string, error) {
+	return c.names(rdb.DBList())
+}
+
+// DeleteUser deletes the user account with the given name, which also removes all of its permissions.
+func (c *sessionClient) DeleteUser(name string) error {
+	_, err := rdb.DB(SystemDB).Table("users").Get(name).Delete().RunWrite(c.session)
+	return err
+}
+
+// DropDB drops the database with the given name and all of its tables.
+func (c *sessionClient) DropDB(name string) error {
+	_, err := rdb.DBDrop(name).RunWrite(c.session)
+	return err
+}
+
+// DropIndex drops the secondary index with the given name from the given table.
+func (c *sessionClient) DropIndex(db string, table string, name string) error {
+	_, err := rdb.DB(db).Table(table).IndexDrop(name).RunWrite(c.session)
+	return err
+}
+
+// DropTable drops the table with the given name from the given database.
+func (c *sessionClient) DropTable(db string, name string) error {
+	_, err := rdb.DB(db).TableDrop(name).RunWrite(c.session)
+	return err
+}
+
+// GrantPermissions sets the permissions of the given user globally, on the given database, or on the given table
+// of the database. Permissions that are not set in the given PermissionSet are removed at that scope.
+func (c *sessionClient) GrantPermissions(user string, database string, table string, permissions PermissionSet) error {
+	// Unset permissions are sent as null, which removes them
+	perms := map[string]interface{}{
+		"read":    permissions.Read,
+		"write":   permissions.Write,
+		"config":  permissions.Config,
+		"connect": permissions.Connect,
+	}
+
+	var term rdb.Term
+	switch {
+	case database == "":
+		// The driver has no top-level grant term, so the global grant is sent as a raw query
+		query, err := json.Marshal([]interface{}{ql2.Term_GRANT, []interface{}{user, perms}})
+		if err != nil {
+			return err
+		}
+		term = rdb.RawQuery(query)
+	case table == "":
+		delete(perms, "connect")
+		term = rdb.DB(database).Grant(user, perms)
+	default:
+		delete(perms, "connect")
+		term = rdb.DB(database).Table(table).Grant(user, perms)
+	}
+
+	_, err := term.RunWrite(c.session)
+	return err
+}
+
+// IndexList returns the names of the secondary indexes of the given table.
+func (c *sessionClient) IndexList(db string, table string) ([]string, error) {
+	return c.names(rdb.DB(db).Table(table).IndexList())
+}
+
+// Jobs returns the documents from the jobs system table.
+func (c *sessionClient) Jobs() ([]Job, error) {
+	jobs := []Job{}
+	err := c.list("jobs", &jobs)
+	return jobs, err
+}
+
+// Permissions returns the documents from the permissions system table.
+func (c *sessionClient) Permissions() ([]Permission, error) {
+	permissions := []Permission{}
+	err := c.list("permissions", &permissions)
+	return permissions, err
+}
+
+// ReconfigureTable changes the number of shards and replicas of the given table.
+func (c *sessionClient) ReconfigureTable(db string, name string, shards int, replicas int) error {
+	_, err := rdb.DB(db).Table(name).Reconfigure(rdb.ReconfigureOpts{
+		Shards:   shards,
+		Replicas: replicas,
+	}).RunWrite(c.session)
+	return err
+}
+
+// RenameServer changes the name of the server with the given ID.
+func (c *sessionClient) RenameServer(id string, name string) error {
+	_, err := rdb.DB(SystemDB).Table("server_config").Get(id).Update(map[string]interface{}{
+		"name": name,
+	}).RunWrite(c.session)
+	return err
+}
+
+// ServerStatus returns the documents from the server_status system table.
+func (c *sessionClient) ServerStatus() ([]ServerStatus, error) {
+	servers := []ServerStatus{}
+	err := c.list("server_status", &servers)
+	return servers, err
+}
+
+// SetWriteHook sets the write hook of the given table to the given serialized function returned by WriteHook, or
+// removes the write hook when the function is nil.
+func (c *sessionClient) SetWriteHook(db string, table string, function []byte) error {
+	if function == nil {
+		return c.setWriteHook(db, table, nil)
+	}
+	return c.setWriteHook(db, table, rdb.Binary(function))
+}
+
+// Stats returns the documents from the stats system table.
+func (c *sessionClient) Stats() ([]Stats, error) {
+	stats := []Stats{}
+	err := c.list("stats", &stats)
+	return stats, err
+}
+
+// Sync flushes the writes of the given table to disk.
+func (c *sessionClient) Sync(db string, table string) error {
+	_, err := rdb.DB(db).Table(table).Sync().RunWrite(c.session)
+	return err
+}
+
+// TableConfig returns the documents from the table_config system table.
+func (c *sessionClient) TableConfig() ([]TableConfig, error) {
+	configs := []TableConfig{}
+	err := c.list("table_config", &configs)
+	return configs, err
+}
+
+// TableList returns the names of the tables in the given database.
+func (c *sessionClient) TableList(db string) ([]string, error) {
+	return c.names(rdb.DB(db).TableList())
+}
+
+// TableStatus returns the documents from the table_status system table.
+func (c *sessionClient) TableStatus() ([]TableStatus, error) {
+	statuses := []TableStatus{}
+	err := c.list("table_status", &statuses)
+	return statuses, err
+}
+
+// UpdateTableShards updates the shard configuration for the table with the given ID.
+func (c *sessionClient) UpdateTableShards(id string, shards []Shard) error {
+	_, err := rdb.DB(SystemDB).Table("table_config").Get(id).Update(map[string]interface{}{
+		"shards": shards,
+	}).RunWrite(c.session)
+	return err
+}
+
+// UpdateTableSettings updates the durability and write acknowledgements of the table with the given ID.
+// Empty settings are left unchanged.
+func (c *sessionClient) UpdateTableSettings(id string, durability string, writeAcks interface{}) error {
+	settings := map[string]interface{}{}
+	if durability != "" {
+		settings["durability"] = durability
+	}
+	if writeAcks != nil {
+		settings["write_acks"] = writeAcks
+	}
+	if len(settings) <= 0 {
+		return nil
+	}
+	_, err := rdb.DB(SystemDB).Table("table_config").Get(id).Update(settings).RunWrite(c.session)
+	return err
+}
+
+// UpdateUserPassword changes the password of the user account with the given name.
+func (c *sessionClient) UpdateUserPassword(name string, password string) error {
+	_, err := rdb.DB(SystemDB).Table("users").Get(name).Update(map[string]interface{}{
+		"password": password,
+	}).RunWrite(c.session)
+	return err
+}
+
+// Users returns the documents from the users system table.
+func (c *sessionClient) Users() ([]User, error) {
+	users := []User{}
+	err := c.list("users", &users)
+	return users, err
+}
+
+// WriteHook returns the write hook of the given table, or nil if the table has no write hook.
+func (c *sessionClient) WriteHook(db string, table string) (*WriteHook, error) {
+	query, err := writeHookQuery(ql2.Term_GET_WRITE_HOOK, db, table)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := query.Run(c.session)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	if cursor.IsNil() {
+		return nil, nil
+	}
+	hook := &WriteHook{}
+	if err = cursor.One(hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// list reads all documents from the given system table into the given result slice.
+func (c *sessionClient) list(table string, result interface{}) error {
+	cursor, err := rdb.DB(SystemDB).Table(table).Run(c.session)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	return cursor.All(result)
+}
+
+// names runs the given query, which returns a list of names.
+func (c *sessionClient) names(term rdb.Term) ([]string, error) {
+	cursor, err := term.Run(c.session)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	names := []string{}
+	err = cursor.All(&names)
+	return names, err
+}
+
+// setWriteHook sets the write hook of the given table to the given hook, which is either a function, the binary of a
+// serialized function or nil to remove the write hook.
+func (c *sessionClient) setWriteHook(db string, table string, hook interface{}) error {
+	query, err := writeHookQuery(ql2.Term_SET_WRITE_HOOK, db, table, hook)
+	if err != nil {
+		return err
+	}
+	_, err = query.RunWrite(c.session)
+	return err
+}
+
+// writeHookQuery returns the write hook term of the given type on the given table with the given arguments. The
+// driver has no write hook terms, so they are sent as raw queries.
+func writeHookQuery(termType ql2.Term_TermType, db string, table string, args ...interface{}) (rdb.Term, error) {
+	tableTerm, err := rdb.DB(db).Table(table).Build()
+	if err != nil {
+		return rdb.Term{}, err
+	}
+
+	built := []interface{}{tableTerm}
+	for _, arg := range args {
+		term, err := rdb.Expr(arg).Build()
+		if err != nil {
+			return rdb.Term{}, err
+		}
+		built = append(built, term)
+	}
+
+	query, err := json.Marshal([]interface{}{termType, built})
+	if err != nil {
+		return rdb.Term{}, err
+	}
+	return rdb.RawQuery(query), nil
+}
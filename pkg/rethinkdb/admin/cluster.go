@@ -0,0 +1,150 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultClusterDomain is the default DNS domain of the Kubernetes cluster.
+	DefaultClusterDomain = "cluster.local"
+
+	// DriverPort is the RethinkDB driver port.
+	DriverPort = 28015
+
+	// PasswordKey is the key for the password in the admin and user Secrets.
+	PasswordKey = "password"
+
+	// UsernameKey is the key for the username in the admin and user Secrets.
+	UsernameKey = "username"
+
+	// adminSecretSuffix is the name suffix of the Secret holding the admin credentials.
+	adminSecretSuffix = "admin"
+)
+
+// ClientFunc opens a new Client for the given RethinkDBCluster, with the given options applied to its configuration.
+type ClientFunc func(kubeClient client.Client, cr *v1alpha1.RethinkDBCluster, opts ...ClientOption) (Client, error)
+
+// ClientOption changes the configuration of a Client opened by a ClientFunc.
+type ClientOption func(cfg *Config)
+
+// AdminSecretName returns the name of the Secret with the admin credentials for the given RethinkDBCluster.
+func AdminSecretName(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, adminSecretSuffix)
+}
+
+// ConfigForCluster returns the configuration for connecting to the given RethinkDBCluster as the admin user.
+// The credentials are read from the <name>-admin Secret and the client certificate from the <name>-client Secret,
+// or the existing client certificate Secret set in the spec. The trusted CAs are read from the <name>-ca ConfigMap.
+// TLS is not configured when the driver port of the cluster serves plaintext.
+func ConfigForCluster(kubeClient client.Client, cr *v1alpha1.RethinkDBCluster) (*Config, error) {
+	adminSecret, err := getSecret(kubeClient, cr, AdminSecretName(cr))
+	if err != nil {
+		return nil, err
+	}
+
+	host := DriverHost(cr)
+	cfg := &Config{
+		Address:  DriverAddress(cr),
+		Username: string(adminSecret.Data[UsernameKey]),
+		Password: string(adminSecret.Data[PasswordKey]),
+	}
+	if !rdbtls.Enabled(cr, rdbtls.DriverKey) {
+		return cfg, nil
+	}
+
+	caConfigMap := &corev1.ConfigMap{}
+	err = kubeClient.Get(context.TODO(), types.NamespacedName{Name: rdbtls.CAConfigMapName(cr), Namespace: cr.ObjectMeta.Namespace}, caConfigMap)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecret, err := getSecret(kubeClient, cr, rdbtls.SecretName(cr, rdbtls.ClientKey))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(clientSecret.Data[corev1.TLSCertKey], clientSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, err
+	}
+
+	// Trust the CA bundle, which holds both the old and new CA during a rotation. The driver certificate is verified
+	// against the host name of the driver Service.
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(caConfigMap.Data[rdbtls.CACertKey])) {
+		return nil, errors.New("no CA certificates found")
+	}
+
+	cfg.TLSConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      roots,
+		ServerName:   host,
+	}
+	return cfg, nil
+}
+
+// DriverAddress returns the host and port of the driver Service for the given RethinkDBCluster.
+func DriverAddress(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s:%d", DriverHost(cr), DriverPort)
+}
+
+// DriverHost returns the host name of the driver Service for the given RethinkDBCluster.
+func DriverHost(cr *v1alpha1.RethinkDBCluster) string {
+	domain := cr.Spec.ClusterDomain
+	if domain == "" {
+		domain = DefaultClusterDomain
+	}
+	return fmt.Sprintf("%s.%s.svc.%s", cr.ObjectMeta.Name, cr.ObjectMeta.Namespace, domain)
+}
+
+// NewClientForCluster opens a new Client to the given RethinkDBCluster as the admin user, with the given options
+// applied to the configuration from ConfigForCluster.
+func NewClientForCluster(kubeClient client.Client, cr *v1alpha1.RethinkDBCluster, opts ...ClientOption) (Client, error) {
+	cfg, err := ConfigForCluster(kubeClient, cr)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return NewClient(cfg)
+}
+
+// WithPassword returns a ClientOption that connects with the given admin password, rather than the password from
+// the admin Secret.
+func WithPassword(password string) ClientOption {
+	return func(cfg *Config) {
+		cfg.Password = password
+	}
+}
+
+// getSecret returns the Secret with the given name in the namespace of the given RethinkDBCluster.
+func getSecret(kubeClient client.Client, cr *v1alpha1.RethinkDBCluster, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := kubeClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.ObjectMeta.Namespace}, secret)
+	return secret, err
+}
@@ -0,0 +1,497 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake provides an in-memory implementation of the admin Client for testing.
+package fake
+
+import (
+	"fmt"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Client is an in-memory admin Client. The system tables are represented by the exported fields, the write hooks
+// are keyed by the ID of the table and the tables flushed to disk are recorded in Synced.
+// If Err is set, it is returned from every method.
+type Client struct {
+	Databases     []string
+	Servers       []admin.ServerStatus
+	Tables        []admin.TableConfig
+	TableStatuses []admin.TableStatus
+	Issues        []admin.Issue
+	StatsDocs     []admin.Stats
+	JobDocs       []admin.Job
+	UserDocs      []admin.User
+	PermDocs      []admin.Permission
+	WriteHooks    map[string]*admin.WriteHook
+	Synced        []string
+
+	Closed   bool
+	Err      error
+	Password string
+}
+
+var _ admin.Client = &Client{}
+
+// NewClientFunc returns an admin.ClientFunc that always returns the given Client. The password set by the options
+// is recorded in the Password field of the Client.
+func NewClientFunc(c *Client) admin.ClientFunc {
+	return func(kubeClient client.Client, cr *v1alpha1.RethinkDBCluster, opts ...admin.ClientOption) (admin.Client, error) {
+		cfg := &admin.Config{}
+		for _, opt := range opts {
+			opt(cfg)
+		}
+		c.Password = cfg.Password
+		return c, nil
+	}
+}
+
+// BlockWrites sets a write hook that rejects every write on the table in the WriteHooks.
+func (c *Client) BlockWrites(db string, table string) error {
+	return c.SetWriteHook(db, table, []byte(fmt.Sprintf("r.error(%q)", admin.WritesBlockedError)))
+}
+
+// Close marks the Client as closed.
+func (c *Client) Close() error {
+	c.Closed = true
+	return c.Err
+}
+
+// CreateDB adds a database to the Databases.
+func (c *Client) CreateDB(name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	for _, db := range c.Databases {
+		if db == name {
+			return fmt.Errorf("database %s already exists", name)
+		}
+	}
+	c.Databases = append(c.Databases, name)
+	return nil
+}
+
+// CreateIndex adds an index to the table in the Tables.
+func (c *Client) CreateIndex(db string, table string, name string, opts admin.IndexOptions) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	t, err := c.table(db, table)
+	if err != nil {
+		return err
+	}
+	for _, index := range t.Indexes {
+		if index == name {
+			return fmt.Errorf("index %s already exists on table %s.%s", name, db, table)
+		}
+	}
+	t.Indexes = append(t.Indexes, name)
+	return nil
+}
+
+// CreateTable adds a table to the Tables and the TableStatuses.
+func (c *Client) CreateTable(db string, name string, opts admin.TableOptions) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	if _, err := c.table(db, name); err == nil {
+		return fmt.Errorf("table %s.%s already exists", db, name)
+	}
+	primaryKey := opts.PrimaryKey
+	if primaryKey == "" {
+		primaryKey = "id"
+	}
+	durability := opts.Durability
+	if durability == "" {
+		durability = "hard"
+	}
+	id := fmt.Sprintf("%s.%s", db, name)
+	c.Tables = append(c.Tables, admin.TableConfig{
+		ID:         id,
+		DB:         db,
+		Name:       name,
+		PrimaryKey: primaryKey,
+		Durability: durability,
+		Indexes:    []string{},
+		Shards:     newShards(opts.Shards, opts.Replicas),
+		WriteAcks:  "majority",
+	})
+	c.TableStatuses = append(c.TableStatuses, admin.TableStatus{
+		ID:     id,
+		DB:     db,
+		Name:   name,
+		Status: admin.TableReadiness{AllReplicasReady: true, ReadyForOutdatedReads: true, ReadyForReads: true, ReadyForWrites: true},
+	})
+	return nil
+}
+
+// CreateUser adds a user to the UserDocs.
+func (c *Client) CreateUser(name string, password string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	for _, user := range c.UserDocs {
+		if user.ID == name {
+			return fmt.Errorf("user %s already exists", name)
+		}
+	}
+	c.UserDocs = append(c.UserDocs, admin.User{ID: name, Password: password != ""})
+	return nil
+}
+
+// CurrentIssues returns the Issues.
+func (c *Client) CurrentIssues() ([]admin.Issue, error) {
+	return c.Issues, c.Err
+}
+
+// DBList returns the Databases.
+func (c *Client) DBList() ([]string, error) {
+	return c.Databases, c.Err
+}
+
+// DeleteUser removes the user and its permissions from the UserDocs and PermDocs.
+func (c *Client) DeleteUser(name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	users := []admin.User{}
+	for _, user := range c.UserDocs {
+		if user.ID != name {
+			users = append(users, user)
+		}
+	}
+	c.UserDocs = users
+
+	perms := []admin.Permission{}
+	for _, perm := range c.PermDocs {
+		if perm.User != name {
+			perms = append(perms, perm)
+		}
+	}
+	c.PermDocs = perms
+	return nil
+}
+
+// DropDB removes the database and its tables from the Databases, Tables and TableStatuses.
+func (c *Client) DropDB(name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	dbs := []string{}
+	for _, db := range c.Databases {
+		if db != name {
+			dbs = append(dbs, db)
+		}
+	}
+	if len(dbs) == len(c.Databases) {
+		return fmt.Errorf("database %s not found", name)
+	}
+	c.Databases = dbs
+
+	for _, t := range c.Tables {
+		if t.DB == name {
+			c.dropTable(t.DB, t.Name)
+		}
+	}
+	return nil
+}
+
+// DropIndex removes an index from the table in the Tables.
+func (c *Client) DropIndex(db string, table string, name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	t, err := c.table(db, table)
+	if err != nil {
+		return err
+	}
+	indexes := []string{}
+	for _, index := range t.Indexes {
+		if index != name {
+			indexes = append(indexes, index)
+		}
+	}
+	if len(indexes) == len(t.Indexes) {
+		return fmt.Errorf("index %s not found on table %s.%s", name, db, table)
+	}
+	t.Indexes = indexes
+	return nil
+}
+
+// DropTable removes a table from the Tables and the TableStatuses.
+func (c *Client) DropTable(db string, name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	if _, err := c.table(db, name); err != nil {
+		return err
+	}
+	c.dropTable(db, name)
+	return nil
+}
+
+// GrantPermissions sets the permissions of the user at the given scope in the PermDocs.
+// The permission document is removed when no permissions are set.
+func (c *Client) GrantPermissions(user string, database string, table string, permissions admin.PermissionSet) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	perms := []admin.Permission{}
+	for _, perm := range c.PermDocs {
+		if perm.User != user || perm.Database != database || perm.Table != table {
+			perms = append(perms, perm)
+		}
+	}
+	if permissions != (admin.PermissionSet{}) {
+		perms = append(perms, admin.Permission{
+			ID:          []string{user, database, table},
+			User:        user,
+			Database:    database,
+			Table:       table,
+			Permissions: permissions,
+		})
+	}
+	c.PermDocs = perms
+	return nil
+}
+
+// IndexList returns the indexes of the table in the Tables.
+func (c *Client) IndexList(db string, table string) ([]string, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	t, err := c.table(db, table)
+	if err != nil {
+		return nil, err
+	}
+	return t.Indexes, nil
+}
+
+// Jobs returns the JobDocs.
+func (c *Client) Jobs() ([]admin.Job, error) {
+	return c.JobDocs, c.Err
+}
+
+// Permissions returns the PermDocs.
+func (c *Client) Permissions() ([]admin.Permission, error) {
+	return c.PermDocs, c.Err
+}
+
+// ReconfigureTable replaces the shards of the table in the Tables.
+func (c *Client) ReconfigureTable(db string, name string, shards int, replicas int) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	t, err := c.table(db, name)
+	if err != nil {
+		return err
+	}
+	t.Shards = newShards(shards, replicas)
+	return nil
+}
+
+// RenameServer changes the name of the server with the given ID in the Servers.
+func (c *Client) RenameServer(id string, name string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	for i := range c.Servers {
+		if c.Servers[i].ID == id {
+			c.Servers[i].Name = name
+			return nil
+		}
+	}
+	return fmt.Errorf("server %s not found", id)
+}
+
+// ServerStatus returns the Servers.
+func (c *Client) ServerStatus() ([]admin.ServerStatus, error) {
+	return c.Servers, c.Err
+}
+
+// SetWriteHook sets the write hook of the table in the WriteHooks, the function is used as the query of the hook.
+// The write hook is removed when the function is nil.
+func (c *Client) SetWriteHook(db string, table string, function []byte) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	t, err := c.table(db, table)
+	if err != nil {
+		return err
+	}
+	if function == nil {
+		delete(c.WriteHooks, t.ID)
+		return nil
+	}
+	if c.WriteHooks == nil {
+		c.WriteHooks = map[string]*admin.WriteHook{}
+	}
+	c.WriteHooks[t.ID] = &admin.WriteHook{Function: function, Query: string(function)}
+	return nil
+}
+
+// Stats returns the StatsDocs.
+func (c *Client) Stats() ([]admin.Stats, error) {
+	return c.StatsDocs, c.Err
+}
+
+// Sync records the table as flushed to disk in Synced.
+func (c *Client) Sync(db string, table string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	t, err := c.table(db, table)
+	if err != nil {
+		return err
+	}
+	c.Synced = append(c.Synced, t.ID)
+	return nil
+}
+
+// TableConfig returns the Tables.
+func (c *Client) TableConfig() ([]admin.TableConfig, error) {
+	return c.Tables, c.Err
+}
+
+// TableList returns the names of the tables of the database in the Tables.
+func (c *Client) TableList(db string) ([]string, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	names := []string{}
+	for _, t := range c.Tables {
+		if t.DB == db {
+			names = append(names, t.Name)
+		}
+	}
+	return names, nil
+}
+
+// TableStatus returns the TableStatuses.
+func (c *Client) TableStatus() ([]admin.TableStatus, error) {
+	return c.TableStatuses, c.Err
+}
+
+// UpdateTableSettings sets the durability and write acks of the table with the given ID.
+func (c *Client) UpdateTableSettings(id string, durability string, writeAcks interface{}) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	for i := range c.Tables {
+		if c.Tables[i].ID == id {
+			if durability != "" {
+				c.Tables[i].Durability = durability
+			}
+			if writeAcks != nil {
+				c.Tables[i].WriteAcks = writeAcks
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("table %s not found", id)
+}
+
+// UpdateTableShards replaces the shards of the table with the given ID.
+func (c *Client) UpdateTableShards(id string, shards []admin.Shard) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	for i := range c.Tables {
+		if c.Tables[i].ID == id {
+			c.Tables[i].Shards = shards
+			return nil
+		}
+	}
+	return fmt.Errorf("table %s not found", id)
+}
+
+// UpdateUserPassword marks the user in the UserDocs as having a password.
+func (c *Client) UpdateUserPassword(name string, password string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	for i := range c.UserDocs {
+		if c.UserDocs[i].ID == name {
+			c.UserDocs[i].Password = password != ""
+			return nil
+		}
+	}
+	return fmt.Errorf("user %s not found", name)
+}
+
+// Users returns the UserDocs.
+func (c *Client) Users() ([]admin.User, error) {
+	return c.UserDocs, c.Err
+}
+
+// WriteHook returns the write hook of the table in the WriteHooks.
+func (c *Client) WriteHook(db string, table string) (*admin.WriteHook, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	t, err := c.table(db, table)
+	if err != nil {
+		return nil, err
+	}
+	return c.WriteHooks[t.ID], nil
+}
+
+// dropTable removes a table from the Tables and the TableStatuses.
+func (c *Client) dropTable(db string, name string) {
+	tables := []admin.TableConfig{}
+	for _, t := range c.Tables {
+		if t.DB != db || t.Name != name {
+			tables = append(tables, t)
+		}
+	}
+	c.Tables = tables
+
+	statuses := []admin.TableStatus{}
+	for _, t := range c.TableStatuses {
+		if t.DB != db || t.Name != name {
+			statuses = append(statuses, t)
+		}
+	}
+	c.TableStatuses = statuses
+}
+
+// table returns the table in the Tables with the given database and name.
+func (c *Client) table(db string, name string) (*admin.TableConfig, error) {
+	for i := range c.Tables {
+		if c.Tables[i].DB == db && c.Tables[i].Name == name {
+			return &c.Tables[i], nil
+		}
+	}
+	return nil, fmt.Errorf("table %s.%s not found", db, name)
+}
+
+// newShards returns the given number of shards, each with the given number of replicas.
+func newShards(shards int, replicas int) []admin.Shard {
+	if shards <= 0 {
+		shards = 1
+	}
+	if replicas <= 0 {
+		replicas = 1
+	}
+	result := make([]admin.Shard, shards)
+	for i := range result {
+		for j := 0; j < replicas; j++ {
+			result[i].Replicas = append(result[i].Replicas, fmt.Sprintf("server-%d", j))
+		}
+		result[i].PrimaryReplica = result[i].Replicas[0]
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"strings"
+	"time"
+)
+
+// CanonicalAddress is a host and port that a server can be reached on.
+type CanonicalAddress struct {
+	Host string `rethinkdb:"host"`
+	Port int    `rethinkdb:"port"`
+}
+
+// ServerNetwork is the network information for a server in the rethinkdb.server_status system table.
+type ServerNetwork struct {
+	CanonicalAddresses []CanonicalAddress `rethinkdb:"canonical_addresses"`
+	ClusterPort        int                `rethinkdb:"cluster_port"`
+	ConnectedTo        map[string]bool    `rethinkdb:"connected_to"`
+	Hostname           string             `rethinkdb:"hostname"`
+	HTTPAdminPort      interface{}        `rethinkdb:"http_admin_port"`
+	ReqlPort           int                `rethinkdb:"reql_port"`
+	TimeConnected      time.Time          `rethinkdb:"time_connected"`
+}
+
+// ServerProcess is the process information for a server in the rethinkdb.server_status system table.
+type ServerProcess struct {
+	Argv        []string  `rethinkdb:"argv"`
+	CacheSizeMB float64   `rethinkdb:"cache_size_mb"`
+	PID         int       `rethinkdb:"pid"`
+	TimeStarted time.Time `rethinkdb:"time_started"`
+	Version     string    `rethinkdb:"version"`
+}
+
+// ServerStatus is a document from the rethinkdb.server_status system table.
+type ServerStatus struct {
+	ID      string        `rethinkdb:"id"`
+	Name    string        `rethinkdb:"name"`
+	Network ServerNetwork `rethinkdb:"network"`
+	Process ServerProcess `rethinkdb:"process"`
+}
+
+// Shard is the configuration of a single shard in the rethinkdb.table_config system table.
+type Shard struct {
+	PrimaryReplica    string   `rethinkdb:"primary_replica"`
+	Replicas          []string `rethinkdb:"replicas"`
+	NonvotingReplicas []string `rethinkdb:"nonvoting_replicas"`
+}
+
+// TableConfig is a document from the rethinkdb.table_config system table.
+type TableConfig struct {
+	ID         string   `rethinkdb:"id"`
+	DB         string   `rethinkdb:"db"`
+	Name       string   `rethinkdb:"name"`
+	PrimaryKey string   `rethinkdb:"primary_key"`
+	Durability string   `rethinkdb:"durability"`
+	Indexes    []string `rethinkdb:"indexes"`
+	Shards     []Shard  `rethinkdb:"shards"`
+
+	// WriteAcks is either "majority", "single" or a list of custom write ack requirements.
+	WriteAcks interface{} `rethinkdb:"write_acks"`
+}
+
+// ReplicaStatus is the status of a single replica in the rethinkdb.table_status system table.
+type ReplicaStatus struct {
+	Server string `rethinkdb:"server"`
+	State  string `rethinkdb:"state"`
+}
+
+// ShardStatus is the status of a single shard in the rethinkdb.table_status system table.
+type ShardStatus struct {
+	PrimaryReplicas []string        `rethinkdb:"primary_replicas"`
+	Replicas        []ReplicaStatus `rethinkdb:"replicas"`
+}
+
+// TableReadiness is the overall readiness of a table in the rethinkdb.table_status system table.
+type TableReadiness struct {
+	AllReplicasReady      bool `rethinkdb:"all_replicas_ready"`
+	ReadyForOutdatedReads bool `rethinkdb:"ready_for_outdated_reads"`
+	ReadyForReads         bool `rethinkdb:"ready_for_reads"`
+	ReadyForWrites        bool `rethinkdb:"ready_for_writes"`
+}
+
+// TableStatus is a document from the rethinkdb.table_status system table.
+type TableStatus struct {
+	ID         string         `rethinkdb:"id"`
+	DB         string         `rethinkdb:"db"`
+	Name       string         `rethinkdb:"name"`
+	RaftLeader string         `rethinkdb:"raft_leader"`
+	Shards     []ShardStatus  `rethinkdb:"shards"`
+	Status     TableReadiness `rethinkdb:"status"`
+}
+
+// Issue is a document from the rethinkdb.current_issues system table.
+type Issue struct {
+	ID          string                 `rethinkdb:"id"`
+	Type        string                 `rethinkdb:"type"`
+	Critical    bool                   `rethinkdb:"critical"`
+	Description string                 `rethinkdb:"description"`
+	Info        map[string]interface{} `rethinkdb:"info"`
+}
+
+// QueryEngineStats are the query engine statistics in the rethinkdb.stats system table.
+// Only the fields relevant to the type of the statistics document are set.
+type QueryEngineStats struct {
+	ClientConnections int     `rethinkdb:"client_connections"`
+	ClientsActive     int     `rethinkdb:"clients_active"`
+	QueriesPerSec     float64 `rethinkdb:"queries_per_sec"`
+	QueriesTotal      int64   `rethinkdb:"queries_total"`
+	ReadDocsPerSec    float64 `rethinkdb:"read_docs_per_sec"`
+	ReadDocsTotal     int64   `rethinkdb:"read_docs_total"`
+	WrittenDocsPerSec float64 `rethinkdb:"written_docs_per_sec"`
+	WrittenDocsTotal  int64   `rethinkdb:"written_docs_total"`
+}
+
+// Stats is a document from the rethinkdb.stats system table.
+// The first element of the ID is the type of the document: cluster, server, table or table_server.
+type Stats struct {
+	ID            []string               `rethinkdb:"id"`
+	Server        string                 `rethinkdb:"server"`
+	DB            string                 `rethinkdb:"db"`
+	Table         string                 `rethinkdb:"table"`
+	QueryEngine   QueryEngineStats       `rethinkdb:"query_engine"`
+	StorageEngine map[string]interface{} `rethinkdb:"storage_engine"`
+}
+
+// Job is a document from the rethinkdb.jobs system table.
+type Job struct {
+	ID          []interface{}          `rethinkdb:"id"`
+	Type        string                 `rethinkdb:"type"`
+	DurationSec float64                `rethinkdb:"duration_sec"`
+	Info        map[string]interface{} `rethinkdb:"info"`
+	Servers     []string               `rethinkdb:"servers"`
+}
+
+// User is a document from the rethinkdb.users system table.
+// The password is never returned, only whether or not a password has been set.
+type User struct {
+	ID       string `rethinkdb:"id"`
+	Password bool   `rethinkdb:"password"`
+}
+
+// PermissionSet is the set of permissions granted to a user.
+// A nil value means the permission is not set at this scope.
+type PermissionSet struct {
+	Read    *bool `rethinkdb:"read,omitempty"`
+	Write   *bool `rethinkdb:"write,omitempty"`
+	Config  *bool `rethinkdb:"config,omitempty"`
+	Connect *bool `rethinkdb:"connect,omitempty"`
+}
+
+// Permission is a document from the rethinkdb.permissions system table.
+// The database and table are empty for global permissions.
+type Permission struct {
+	ID          []string      `rethinkdb:"id"`
+	User        string        `rethinkdb:"user"`
+	Database    string        `rethinkdb:"database,omitempty"`
+	Table       string        `rethinkdb:"table,omitempty"`
+	Permissions PermissionSet `rethinkdb:"permissions"`
+}
+
+// TableOptions are the options for creating a table. Unset options use the RethinkDB defaults.
+type TableOptions struct {
+	PrimaryKey string
+	Durability string
+	Shards     int
+	Replicas   int
+}
+
+// IndexOptions define a secondary index. The index is on the field with the name of the index when no fields are
+// given, and a compound index when multiple fields are given.
+type IndexOptions struct {
+	Fields []string
+	Multi  bool
+	Geo    bool
+}
+
+// WriteHook is the write hook of a table, as returned by get_write_hook. The serialized Function is passed back as is
+// to restore the hook.
+type WriteHook struct {
+	Function []byte `rethinkdb:"function"`
+	Query    string `rethinkdb:"query"`
+}
+
+// BlocksWrites returns true if the write hook was set by BlockWrites.
+func (h *WriteHook) BlocksWrites() bool {
+	return h != nil && strings.Contains(h.Query, WritesBlockedError)
+}
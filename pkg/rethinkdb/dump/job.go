@@ -0,0 +1,288 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dump builds the Jobs that dump a RethinkDB cluster to an archive with rethinkdb dump, restore an archive
+// into a cluster with rethinkdb restore or verify an archive, and reads the outcome the Jobs report.
+package dump
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	"github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/admin"
+	rdbtls "github.com/jmckind/rethinkdb-operator/pkg/rethinkdb/tls"
+	"github.com/jmckind/rethinkdb-operator/version"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// adminVolume is the name of the volume with the admin Secret of the cluster.
+	adminVolume = "admin"
+
+	// archivePath is the path the archive is written to and read from in the container.
+	archivePath = "/backup"
+
+	// archiveVolume is the name of the volume the archive is written to and read from.
+	archiveVolume = "backup-data"
+
+	// backupBackoffLimit is the number of retries before a backup Job is marked as failed.
+	backupBackoffLimit = 2
+
+	// caVolume is the name of the volume with the CA ConfigMap of the cluster.
+	caVolume = "ca"
+
+	// clientVolume is the name of the volume with the client certificate Secret of the cluster.
+	clientVolume = "client"
+
+	// containerName is the name of the container that runs rethinkdb dump or restore.
+	containerName = "rethinkdb-dump"
+
+	// defaultEncryptionKey is the default key of the passphrase in the encryption key Secret.
+	defaultEncryptionKey = "key"
+
+	// restoreBackoffLimit is the number of retries before a restore Job is marked as failed. A partially restored
+	// archive is not retried, as the retry would be refused for the tables that were already restored.
+	restoreBackoffLimit = 0
+
+	// s3AccessKeyIDKey is the key for the access key ID in the S3 credentials Secret.
+	s3AccessKeyIDKey = "accessKeyId"
+
+	// s3SecretAccessKeyKey is the key for the secret access key in the S3 credentials Secret.
+	s3SecretAccessKeyKey = "secretAccessKey"
+
+	// secretsPath is the path the admin and client Secrets of the cluster are mounted at.
+	secretsPath = "/etc/rethinkdb"
+)
+
+// command is the command of the container, it runs the script with the Python packages of the image.
+var command = []string{"/bin/sh", "-c", `exec python -c "$SCRIPT"`}
+
+// defaultImage is the default container image that runs rethinkdb dump and restore, it is built from
+// build/dump/Dockerfile with the pinned packages of build/dump/requirements.txt.
+var defaultImage = fmt.Sprintf("jmckind/rethinkdb-operator-dump:%s", version.Version)
+
+// NewBackupJobSpec returns the spec of a Job that dumps the given RethinkDBCluster to an archive for the given
+// RethinkDBBackup, or that flushes every table to disk when the backup is taken with volume snapshots. The Pods of
+// the Job are created with the given labels.
+func NewBackupJobSpec(backup *v1alpha1.RethinkDBBackup, cluster *v1alpha1.RethinkDBCluster, labels map[string]string) batchv1.JobSpec {
+	env := []corev1.EnvVar{
+		{Name: "BACKUP_NAME", Value: backup.Name},
+		{Name: "BACKUP_TARGETS", Value: strings.Join(targets(backup.Spec.Databases, backup.Spec.Tables), " ")},
+	}
+	if retention := backup.Spec.Retention; retention != nil {
+		env = append(env,
+			corev1.EnvVar{Name: "RETENTION_KEEP_LAST", Value: fmt.Sprint(retention.KeepLast)},
+			corev1.EnvVar{Name: "RETENTION_KEEP_DAILY", Value: fmt.Sprint(retention.KeepDaily)},
+			corev1.EnvVar{Name: "RETENTION_KEEP_WEEKLY", Value: fmt.Sprint(retention.KeepWeekly)},
+			corev1.EnvVar{Name: "RETENTION_KEEP_MONTHLY", Value: fmt.Sprint(retention.KeepMonthly)},
+		)
+	}
+	script := backupScript
+	if backup.Spec.Storage.VolumeSnapshot != nil {
+		script = flushScript
+	}
+	return newJobSpec(cluster, &backup.Spec.Storage, backup.Spec.Image, labels, script, env, backupBackoffLimit)
+}
+
+// NewRestoreJobSpec returns the spec of a Job that restores an archive into the given RethinkDBCluster as defined by
+// the given restore spec, the cluster reference of the spec is not used. The Pods of the Job are created with the
+// given labels.
+func NewRestoreJobSpec(spec *v1alpha1.RethinkDBRestoreSpec, cluster *v1alpha1.RethinkDBCluster, labels map[string]string) batchv1.JobSpec {
+	return newJobSpec(cluster, &spec.Source.Storage, spec.Image, labels, restoreScript, restoreEnv(spec), restoreBackoffLimit)
+}
+
+// NewVerifyJobSpec returns the spec of a Job that restores the given archive of the given RethinkDBBackup into the
+// given throwaway RethinkDBCluster, and compares the number of documents in each restored table with the archive.
+// The Pods of the Job are created with the given labels.
+func NewVerifyJobSpec(backup *v1alpha1.RethinkDBBackup, archive string, cluster *v1alpha1.RethinkDBCluster, labels map[string]string) batchv1.JobSpec {
+	spec := &v1alpha1.RethinkDBRestoreSpec{
+		Source: v1alpha1.RethinkDBRestoreSource{
+			Archive: archive,
+			Storage: backup.Spec.Storage,
+		},
+		Force: true,
+		Image: backup.Spec.Image,
+	}
+	env := append(restoreEnv(spec), corev1.EnvVar{Name: "RESTORE_VERIFY", Value: "true"})
+	return newJobSpec(cluster, &spec.Source.Storage, spec.Image, labels, restoreScript, env, restoreBackoffLimit)
+}
+
+// clusterEnv returns the environment to connect to the given RethinkDBCluster and to access the given storage.
+func clusterEnv(cluster *v1alpha1.RethinkDBCluster, storage *v1alpha1.RethinkDBBackupStorage) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{Name: "ARCHIVE_DIR", Value: archivePath},
+		{Name: "RETHINKDB_ADDRESS", Value: admin.DriverAddress(cluster)},
+		{Name: "RETHINKDB_PASSWORD_FILE", Value: mountPath(adminVolume, admin.PasswordKey)},
+	}
+
+	if rdbtls.Enabled(cluster, rdbtls.DriverKey) {
+		env = append(env,
+			corev1.EnvVar{Name: "RETHINKDB_TLS_CA", Value: mountPath(caVolume, rdbtls.CACertKey)},
+			corev1.EnvVar{Name: "RETHINKDB_TLS_CERT", Value: mountPath(clientVolume, corev1.TLSCertKey)},
+			corev1.EnvVar{Name: "RETHINKDB_TLS_KEY", Value: mountPath(clientVolume, corev1.TLSPrivateKeyKey)},
+		)
+	}
+
+	if s3 := storage.S3; s3 != nil {
+		env = append(env,
+			corev1.EnvVar{Name: "S3_ENDPOINT", Value: s3.Endpoint},
+			corev1.EnvVar{Name: "S3_REGION", Value: s3.Region},
+			corev1.EnvVar{Name: "S3_BUCKET", Value: s3.Bucket},
+			corev1.EnvVar{Name: "S3_PREFIX", Value: s3.Prefix},
+			secretEnv("AWS_ACCESS_KEY_ID", s3.CredentialsSecret, s3AccessKeyIDKey),
+			secretEnv("AWS_SECRET_ACCESS_KEY", s3.CredentialsSecret, s3SecretAccessKeyKey),
+		)
+	}
+
+	if encryption := storage.Encryption; encryption != nil {
+		key := encryption.Key
+		if key == "" {
+			key = defaultEncryptionKey
+		}
+		env = append(env, secretEnv("ENCRYPTION_KEY", encryption.KeySecret, key))
+	}
+	return env
+}
+
+// mountPath returns the path of the given key in the given Secret or ConfigMap volume of the container, or the path
+// of the volume when the key is empty.
+func mountPath(volume string, key string) string {
+	if key == "" {
+		return fmt.Sprintf("%s/%s", secretsPath, volume)
+	}
+	return fmt.Sprintf("%s/%s/%s", secretsPath, volume, key)
+}
+
+// newJobSpec returns the spec of a Job that runs the given script with the given environment against the given
+// RethinkDBCluster, with the given archive storage mounted.
+func newJobSpec(cluster *v1alpha1.RethinkDBCluster, storage *v1alpha1.RethinkDBBackupStorage, image string, labels map[string]string,
+	script string, env []corev1.EnvVar, backoffLimit int32) batchv1.JobSpec {
+	if image == "" {
+		image = defaultImage
+	}
+	volumes, mounts := volumes(cluster, storage)
+
+	env = append([]corev1.EnvVar{{Name: "SCRIPT", Value: script}}, env...)
+	env = append(env, clusterEnv(cluster, storage)...)
+
+	return batchv1.JobSpec{
+		BackoffLimit: &backoffLimit,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:                     containerName,
+					Image:                    image,
+					Command:                  command,
+					Env:                      env,
+					VolumeMounts:             mounts,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				}},
+				RestartPolicy: corev1.RestartPolicyNever,
+				Volumes:       volumes,
+			},
+		},
+	}
+}
+
+// restoreEnv returns the environment of the restore script for the given restore spec.
+func restoreEnv(spec *v1alpha1.RethinkDBRestoreSpec) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{Name: "RESTORE_ARCHIVE", Value: spec.Source.Archive},
+		{Name: "RESTORE_TARGETS", Value: strings.Join(targets(spec.Databases, spec.Tables), " ")},
+		{Name: "RESTORE_FORCE", Value: strconv.FormatBool(spec.Force)},
+	}
+	if spec.Shards != nil {
+		env = append(env, corev1.EnvVar{Name: "RESTORE_SHARDS", Value: fmt.Sprint(*spec.Shards)})
+	}
+	if spec.Replicas != nil {
+		env = append(env, corev1.EnvVar{Name: "RESTORE_REPLICAS", Value: fmt.Sprint(*spec.Replicas)})
+	}
+	return env
+}
+
+// secretEnv returns an environment variable with the value of the given key in the given Secret.
+func secretEnv(name string, secret string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// targets returns the given databases and tables as the targets of rethinkdb dump or restore, all of the databases
+// and tables are included when empty.
+func targets(databases []string, tables []string) []string {
+	targets := []string{}
+	targets = append(targets, databases...)
+	targets = append(targets, tables...)
+	return targets
+}
+
+// volumes returns the volumes and the mounts of the container for the given RethinkDBCluster and archive storage.
+func volumes(cluster *v1alpha1.RethinkDBCluster, storage *v1alpha1.RethinkDBBackupStorage) ([]corev1.Volume, []corev1.VolumeMount) {
+	data := corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	if storage.PersistentVolumeClaim != nil {
+		data = corev1.VolumeSource{PersistentVolumeClaim: storage.PersistentVolumeClaim.DeepCopy()}
+	}
+
+	volumes := []corev1.Volume{
+		{Name: archiveVolume, VolumeSource: data},
+		{
+			Name: adminVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: admin.AdminSecretName(cluster)},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: archiveVolume, MountPath: archivePath},
+		{Name: adminVolume, MountPath: mountPath(adminVolume, ""), ReadOnly: true},
+	}
+
+	if rdbtls.Enabled(cluster, rdbtls.DriverKey) {
+		volumes = append(volumes,
+			corev1.Volume{
+				Name: caVolume,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: rdbtls.CAConfigMapName(cluster)},
+					},
+				},
+			},
+			corev1.Volume{
+				Name: clientVolume,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: rdbtls.SecretName(cluster, rdbtls.ClientKey)},
+				},
+			},
+		)
+		mounts = append(mounts,
+			corev1.VolumeMount{Name: caVolume, MountPath: mountPath(caVolume, ""), ReadOnly: true},
+			corev1.VolumeMount{Name: clientVolume, MountPath: mountPath(clientVolume, ""), ReadOnly: true},
+		)
+	}
+	return volumes, mounts
+}
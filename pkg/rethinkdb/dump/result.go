@@ -0,0 +1,116 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+import (
+	"encoding/json"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Result is the outcome of a backup or restore written by the script to the termination log of the container.
+type Result struct {
+	// Archive is the location of the archive, a path on the claim or an s3:// URL.
+	Archive string `json:"archive"`
+
+	// Size is the size of the archive in bytes.
+	Size int64 `json:"size"`
+
+	// Seconds is the time it took to dump or restore the archive.
+	Seconds float64 `json:"duration"`
+
+	// Tables are the dumped, restored or verified tables, the existing tables that stopped a restore, or the tables
+	// with a different number of documents when a verification failed.
+	Tables []string `json:"tables"`
+
+	// Pruned are the archives deleted by the retention policy after a backup.
+	Pruned []string `json:"pruned"`
+
+	// Message is a problem that did not fail the Job, such as archives that could not be pruned.
+	Message string `json:"message"`
+
+	// Error is the reason the Job failed.
+	Error string `json:"error"`
+
+	// Reason is a CamelCase reason for a failure that was detected by the script.
+	Reason string `json:"reason"`
+}
+
+// Duration returns the time it took to dump or restore the archive.
+func (r *Result) Duration() *metav1.Duration {
+	return &metav1.Duration{Duration: time.Duration(r.Seconds * float64(time.Second))}
+}
+
+// IsJobFailed returns true if the given Job has failed.
+func IsJobFailed(job *batchv1.Job) bool {
+	return hasJobCondition(job, batchv1.JobFailed)
+}
+
+// IsJobSucceeded returns true if the given Job has completed successfully.
+func IsJobSucceeded(job *batchv1.Job) bool {
+	return hasJobCondition(job, batchv1.JobComplete)
+}
+
+// JobFinishTime returns the time the given Job succeeded or failed, or nil if the Job has not finished.
+func JobFinishTime(job *batchv1.Job) *metav1.Time {
+	if job.Status.CompletionTime != nil {
+		return job.Status.CompletionTime
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// ReadResult returns the outcome written to the termination log by the given Pods of a backup or restore Job, or
+// nil if none of the Pods has written it. The most recent outcome is returned, as a failed Job may have retried.
+func ReadResult(pods []corev1.Pod) *Result {
+	var latest *corev1.ContainerStateTerminated
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if status.Name != containerName || terminated == nil || terminated.Message == "" {
+				continue
+			}
+			if latest == nil || latest.FinishedAt.Before(&terminated.FinishedAt) {
+				latest = terminated
+			}
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+
+	result := &Result{}
+	if err := json.Unmarshal([]byte(latest.Message), result); err != nil {
+		return &Result{Error: latest.Message}
+	}
+	return result
+}
+
+// hasJobCondition returns true if the condition of the given type is true on the given Job.
+func hasJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
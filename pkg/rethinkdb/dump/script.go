@@ -0,0 +1,386 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+// scriptPrelude holds the helpers shared by the Python programs run by the backup and restore Jobs. The outcome of
+// a Job is written as JSON to the termination log of the container, where it is read by the operator.
+//
+// The Python driver has no option for a client certificate, so a sitecustomize module is added to the path of the
+// rethinkdb dump and restore processes that loads the mounted client certificate into every TLS context that trusts
+// the cluster CA. The same patch is applied to the script itself.
+//
+// Encrypted archives are written with a header, the salt of the key derived from the passphrase and the nonce,
+// followed by the archive encrypted with AES-256-GCM and the authentication tag.
+const scriptPrelude = `
+import datetime
+import json
+import os
+import re
+import subprocess
+import sys
+import tarfile
+import tempfile
+import time
+
+SITECUSTOMIZE = '''
+import os
+import ssl
+
+_load_verify_locations = ssl.SSLContext.load_verify_locations
+
+def load_verify_locations(self, *args, **kwargs):
+    _load_verify_locations(self, *args, **kwargs)
+    if os.environ.get('RETHINKDB_TLS_CERT'):
+        self.load_cert_chain(os.environ['RETHINKDB_TLS_CERT'], os.environ['RETHINKDB_TLS_KEY'])
+
+ssl.SSLContext.load_verify_locations = load_verify_locations
+'''
+
+
+ARCHIVE_TIMESTAMP = '%Y%m%dT%H%M%SZ'
+ENCRYPTION_CHUNK = 1024 * 1024
+ENCRYPTION_MAGIC = b'RDBENC1\n'
+ENCRYPTION_SUFFIX = '.enc'
+
+
+def terminate(result, code):
+    message = json.dumps(result)
+    while len(message) > 4000 and (result.get('pruned') or result.get('tables')):
+        result['pruned' if result.get('pruned') else 'tables'].pop()
+        message = json.dumps(result)
+    with open('/dev/termination-log', 'w') as log:
+        log.write(message)
+    sys.exit(code)
+
+
+def tool_env():
+    site = tempfile.mkdtemp()
+    with open(os.path.join(site, 'sitecustomize.py'), 'w') as module:
+        module.write(SITECUSTOMIZE)
+    exec(SITECUSTOMIZE)
+    return dict(os.environ, PYTHONPATH=site)
+
+
+def tool_args(tool):
+    args = [sys.executable, '-m', 'rethinkdb', tool, '--connect', os.environ['RETHINKDB_ADDRESS'],
+            '--password-file', os.environ['RETHINKDB_PASSWORD_FILE']]
+    if os.environ.get('RETHINKDB_TLS_CA'):
+        args += ['--tls-cert', os.environ['RETHINKDB_TLS_CA']]
+    return args
+
+
+def archive_tables(path):
+    tables = []
+    with tarfile.open(path) as archive:
+        for member in archive.getnames():
+            if member.endswith('.info'):
+                parts = member.split('/')
+                tables.append('%s.%s' % (parts[-2], parts[-1][:-len('.info')]))
+    return sorted(tables)
+
+
+def archive_documents(path):
+    counts = {}
+    with tarfile.open(path) as archive:
+        for member in archive.getmembers():
+            if not member.isfile() or not member.name.endswith('.json'):
+                continue
+            parts = member.name.split('/')
+            # rethinkdb dump writes every document of a table on its own line, between the lines of the JSON array.
+            count = 0
+            for line in archive.extractfile(member):
+                if line.strip() not in (b'', b'[', b']'):
+                    count += 1
+            counts['%s.%s' % (parts[-2], parts[-1][:-len('.json')])] = count
+    return counts
+
+
+def cipher(salt, nonce, tag=None):
+    from cryptography.hazmat.backends import default_backend
+    from cryptography.hazmat.primitives import hashes
+    from cryptography.hazmat.primitives.ciphers import Cipher, algorithms, modes
+    from cryptography.hazmat.primitives.kdf.pbkdf2 import PBKDF2HMAC
+
+    kdf = PBKDF2HMAC(algorithm=hashes.SHA256(), length=32, salt=salt, iterations=200000, backend=default_backend())
+    key = kdf.derive(os.environ['ENCRYPTION_KEY'].encode('utf-8'))
+    return Cipher(algorithms.AES(key), modes.GCM(nonce, tag), backend=default_backend())
+
+
+def encrypt(source, target):
+    salt, nonce = os.urandom(16), os.urandom(12)
+    encryptor = cipher(salt, nonce).encryptor()
+    with open(source, 'rb') as plain, open(target, 'wb') as sealed:
+        sealed.write(ENCRYPTION_MAGIC + salt + nonce)
+        for chunk in iter(lambda: plain.read(ENCRYPTION_CHUNK), b''):
+            sealed.write(encryptor.update(chunk))
+        sealed.write(encryptor.finalize() + encryptor.tag)
+
+
+def decrypt(source, target):
+    if not os.environ.get('ENCRYPTION_KEY'):
+        raise Exception('archive %s is encrypted and no encryption key is set' % os.path.basename(source))
+    header = len(ENCRYPTION_MAGIC) + 28
+    remaining = os.path.getsize(source) - header - 16
+    with open(source, 'rb') as sealed, open(target, 'wb') as plain:
+        if remaining < 0 or sealed.read(len(ENCRYPTION_MAGIC)) != ENCRYPTION_MAGIC:
+            raise Exception('archive %s is not encrypted by a backup' % os.path.basename(source))
+        salt, nonce = sealed.read(16), sealed.read(12)
+        sealed.seek(-16, os.SEEK_END)
+        decryptor = cipher(salt, nonce, sealed.read(16)).decryptor()
+        sealed.seek(header)
+        while remaining > 0:
+            chunk = sealed.read(min(ENCRYPTION_CHUNK, remaining))
+            remaining -= len(chunk)
+            plain.write(decryptor.update(chunk))
+        try:
+            decryptor.finalize()
+        except Exception:
+            raise Exception('unable to decrypt archive %s, the encryption key does not match' % os.path.basename(source))
+
+
+def s3_client():
+    import boto3
+    import botocore.config
+
+    endpoint = os.environ.get('S3_ENDPOINT') or None
+    config = botocore.config.Config(s3={'addressing_style': 'path'}) if endpoint else None
+    return boto3.client('s3', endpoint_url=endpoint, region_name=os.environ.get('S3_REGION') or None, config=config)
+
+
+def s3_location(name):
+    if name.startswith('s3://'):
+        bucket, key = name[len('s3://'):].split('/', 1)
+        return bucket, key
+    prefix = os.environ.get('S3_PREFIX', '').strip('/')
+    return os.environ['S3_BUCKET'], '%s/%s' % (prefix, name) if prefix else name
+
+
+def connect():
+    from rethinkdb import RethinkDB
+
+    r = RethinkDB()
+    host, port = os.environ['RETHINKDB_ADDRESS'].rsplit(':', 1)
+    with open(os.environ['RETHINKDB_PASSWORD_FILE']) as password:
+        options = {'host': host, 'port': int(port), 'user': 'admin', 'password': password.read().rstrip('\r\n')}
+    if os.environ.get('RETHINKDB_TLS_CA'):
+        options['ssl'] = {'ca_certs': os.environ['RETHINKDB_TLS_CA']}
+    return r, r.connect(**options)
+
+
+def run(main):
+    try:
+        main()
+    except Exception as e:
+        terminate({'error': str(e)}, 1)
+`
+
+// backupScript is the Python program run by the backup Job. It runs rethinkdb dump against the cluster, encrypts the
+// archive when an encryption key is set, uploads the archive when S3 storage is used, and deletes the archives that
+// are no longer kept by the retention policy.
+const backupScript = scriptPrelude + `
+
+RETENTION_PERIODS = (
+    ('RETENTION_KEEP_DAILY', lambda taken: taken.date()),
+    ('RETENTION_KEEP_WEEKLY', lambda taken: taken.isocalendar()[:2]),
+    ('RETENTION_KEEP_MONTHLY', lambda taken: (taken.year, taken.month)),
+)
+
+
+def archive_time(name):
+    pattern = r'^%s-(\d{8}T\d{6}Z)\.tar\.gz(%s)?$' % (re.escape(os.environ['BACKUP_NAME']), re.escape(ENCRYPTION_SUFFIX))
+    match = re.match(pattern, name)
+    return datetime.datetime.strptime(match.group(1), ARCHIVE_TIMESTAMP) if match else None
+
+
+def expired(archives, current):
+    archives = sorted(archives, reverse=True)
+    keep = set([current] + [name for _, name in archives[:int(os.environ.get('RETENTION_KEEP_LAST') or 0)]])
+    for variable, period in RETENTION_PERIODS:
+        count, periods = int(os.environ.get(variable) or 0), set()
+        for taken, name in archives:
+            if len(periods) >= count:
+                break
+            if period(taken) not in periods:
+                periods.add(period(taken))
+                keep.add(name)
+    return [name for _, name in archives if name not in keep]
+
+
+def prune(current):
+    pruned = []
+    if os.environ.get('S3_BUCKET'):
+        client = s3_client()
+        bucket, prefix = s3_location(os.environ['BACKUP_NAME'] + '-')
+        keys = {}
+        for page in client.get_paginator('list_objects_v2').paginate(Bucket=bucket, Prefix=prefix):
+            for item in page.get('Contents', []):
+                keys[item['Key'].rsplit('/', 1)[-1]] = item['Key']
+        archives = [(archive_time(name), name) for name in keys if archive_time(name)]
+        for name in expired(archives, current):
+            client.delete_object(Bucket=bucket, Key=keys[name])
+            pruned.append('s3://%s/%s' % (bucket, keys[name]))
+    else:
+        archives = [(archive_time(name), name) for name in os.listdir(os.environ['ARCHIVE_DIR']) if archive_time(name)]
+        for name in expired(archives, current):
+            os.remove(os.path.join(os.environ['ARCHIVE_DIR'], name))
+            pruned.append(name)
+    return sorted(pruned)
+
+
+def main():
+    env = tool_env()
+    timestamp = datetime.datetime.utcnow().strftime(ARCHIVE_TIMESTAMP)
+    name = '%s-%s.tar.gz' % (os.environ['BACKUP_NAME'], timestamp)
+    path = os.path.join(os.environ['ARCHIVE_DIR'], name)
+
+    args = tool_args('dump') + ['--file', path]
+    for target in os.environ.get('BACKUP_TARGETS', '').split():
+        args += ['--export', target]
+
+    start = time.time()
+    subprocess.check_call(args, env=env)
+    tables = archive_tables(path)
+
+    if os.environ.get('ENCRYPTION_KEY'):
+        encrypt(path, path + ENCRYPTION_SUFFIX)
+        os.remove(path)
+        name, path = name + ENCRYPTION_SUFFIX, path + ENCRYPTION_SUFFIX
+    size = os.path.getsize(path)
+
+    archive = name
+    if os.environ.get('S3_BUCKET'):
+        bucket, key = s3_location(name)
+        s3_client().upload_file(path, bucket, key)
+        os.remove(path)
+        archive = 's3://%s/%s' % (bucket, key)
+
+    result = {'archive': archive, 'size': size, 'duration': time.time() - start, 'tables': tables}
+    if os.environ.get('RETENTION_KEEP_LAST') is not None:
+        try:
+            result['pruned'] = prune(name)
+        except Exception as e:
+            result['message'] = 'unable to prune archives: %s' % e
+    terminate(result, 0)
+
+
+run(main)
+`
+
+// flushScript is the Python program run by the backup Job of a backup taken with volume snapshots. It flushes the
+// writes of every table to disk with sync and records the tables. The operator then blocks the writes with a write
+// hook on every table and flushes them again before it snapshots the data volume claims of the servers.
+const flushScript = scriptPrelude + `
+
+def main():
+    tool_env()
+    start = time.time()
+    r, conn = connect()
+    try:
+        tables = []
+        for table in r.db('rethinkdb').table('table_config').pluck('db', 'name').run(conn):
+            r.db(table['db']).table(table['name']).sync().run(conn)
+            tables.append('%s.%s' % (table['db'], table['name']))
+    finally:
+        conn.close()
+    terminate({'archive': '', 'size': 0, 'duration': time.time() - start, 'tables': sorted(tables)}, 0)
+
+
+run(main)
+`
+
+// restoreScript is the Python program run by the restore and verify Jobs. It downloads the archive when S3 storage
+// is used, decrypts an encrypted archive, refuses to restore into existing tables unless forced, and runs rethinkdb
+// restore against the cluster. A verify Job then compares the number of documents in each restored table with the
+// archive.
+const restoreScript = scriptPrelude + `
+
+def selected(table, targets):
+    return not targets or table in targets or table.split('.')[0] in targets
+
+
+def existing_tables():
+    r, conn = connect()
+    try:
+        tables = r.db('rethinkdb').table('table_config').pluck('db', 'name').run(conn)
+        return set('%s.%s' % (table['db'], table['name']) for table in tables)
+    finally:
+        conn.close()
+
+
+def verify(path, tables):
+    dumped = archive_documents(path)
+    r, conn = connect()
+    try:
+        mismatches = []
+        for table in tables:
+            db, name = table.split('.', 1)
+            restored = r.db(db).table(name).count().run(conn)
+            if restored != dumped.get(table, 0):
+                mismatches.append('%s (%d dumped, %d restored)' % (table, dumped.get(table, 0), restored))
+    finally:
+        conn.close()
+    if mismatches:
+        terminate({'error': 'document counts differ: %s' % ', '.join(mismatches), 'reason': 'CountMismatch',
+                   'tables': [mismatch.split(' ', 1)[0] for mismatch in mismatches]}, 1)
+
+
+def main():
+    env = tool_env()
+    archive = os.environ['RESTORE_ARCHIVE']
+    path = os.path.join(os.environ['ARCHIVE_DIR'], archive)
+    if os.environ.get('S3_BUCKET'):
+        bucket, key = s3_location(archive)
+        path = os.path.join(os.environ['ARCHIVE_DIR'], os.path.basename(key))
+        s3_client().download_file(bucket, key, path)
+        archive = 's3://%s/%s' % (bucket, key)
+    size = os.path.getsize(path)
+
+    if path.endswith(ENCRYPTION_SUFFIX):
+        # The claim of the archive may be mounted read-only, a downloaded archive is decrypted next to the download.
+        work = tempfile.mkdtemp(dir=os.environ['ARCHIVE_DIR'] if os.environ.get('S3_BUCKET') else None)
+        plain = os.path.join(work, os.path.basename(path)[:-len(ENCRYPTION_SUFFIX)])
+        decrypt(path, plain)
+        path = plain
+
+    targets = os.environ.get('RESTORE_TARGETS', '').split()
+    tables = [table for table in archive_tables(path) if selected(table, targets)]
+    if not tables:
+        raise Exception('no tables in archive %s match %s' % (archive, ' '.join(targets) or 'the restore'))
+
+    if os.environ.get('RESTORE_FORCE') != 'true':
+        conflicts = sorted(existing_tables().intersection(tables))
+        if conflicts:
+            terminate({'error': 'tables already exist in the cluster: %s' % ', '.join(conflicts), 'reason': 'TablesExist',
+                       'tables': conflicts}, 1)
+
+    args = tool_args('restore') + [path]
+    for target in targets:
+        args += ['--import', target]
+    if os.environ.get('RESTORE_FORCE') == 'true':
+        args += ['--force']
+    if os.environ.get('RESTORE_SHARDS'):
+        args += ['--shards', os.environ['RESTORE_SHARDS']]
+    if os.environ.get('RESTORE_REPLICAS'):
+        args += ['--replicas', os.environ['RESTORE_REPLICAS']]
+
+    start = time.time()
+    subprocess.check_call(args, env=env)
+    if os.environ.get('RESTORE_VERIFY') == 'true':
+        verify(path, tables)
+    terminate({'archive': archive, 'size': size, 'duration': time.time() - start, 'tables': tables}, 0)
+
+
+run(main)
+`
@@ -0,0 +1,87 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+)
+
+// ValidateArchiveStorage returns an error if archives can not be restored from the given storage. Volume snapshots
+// are not archives, they are restored by provisioning the data volume claims of a new cluster from them.
+func ValidateArchiveStorage(storage *v1alpha1.RethinkDBBackupStorage) error {
+	if storage.VolumeSnapshot != nil {
+		return errors.New("volumeSnapshot storage can only be restored with the bootstrap of a new cluster")
+	}
+	return ValidateStorage(storage)
+}
+
+// ValidateRetention returns an error if the given retention would delete every archive.
+func ValidateRetention(retention *v1alpha1.RethinkDBBackupRetention) error {
+	if retention == nil {
+		return nil
+	}
+	keep := []int32{retention.KeepLast, retention.KeepDaily, retention.KeepWeekly, retention.KeepMonthly}
+	total := int32(0)
+	for _, count := range keep {
+		if count < 0 {
+			return errors.New("retention counts must not be negative")
+		}
+		total += count
+	}
+	if total == 0 {
+		return errors.New("retention must keep at least one archive")
+	}
+	return nil
+}
+
+// ValidateStorage returns an error if the given archive storage can not be used.
+func ValidateStorage(storage *v1alpha1.RethinkDBBackupStorage) error {
+	kinds := 0
+	for _, set := range []bool{storage.PersistentVolumeClaim != nil, storage.S3 != nil, storage.VolumeSnapshot != nil} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return errors.New("exactly one of persistentVolumeClaim, s3 or volumeSnapshot storage is required")
+	}
+	if storage.PersistentVolumeClaim != nil && storage.PersistentVolumeClaim.ClaimName == "" {
+		return errors.New("persistentVolumeClaim claimName is required")
+	}
+	if storage.S3 != nil && (storage.S3.Bucket == "" || storage.S3.CredentialsSecret == "") {
+		return errors.New("s3 bucket and credentialsSecret are required")
+	}
+	if storage.Encryption != nil && storage.Encryption.KeySecret == "" {
+		return errors.New("encryption keySecret is required")
+	}
+	if storage.Encryption != nil && storage.VolumeSnapshot != nil {
+		return errors.New("volumeSnapshot storage can not be encrypted")
+	}
+	return nil
+}
+
+// ValidateTables returns an error if any of the given tables is not in database.table form.
+func ValidateTables(tables []string) error {
+	for _, table := range tables {
+		if parts := strings.Split(table, "."); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("table %s must be in database.table form", table)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot builds the CSI VolumeSnapshots of the data volume claims of a RethinkDB cluster. VolumeSnapshots
+// are handled as unstructured objects, so the operator does not depend on the external snapshotter API.
+package snapshot
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Group is the API group of the VolumeSnapshot resource.
+	Group = "snapshot.storage.k8s.io"
+
+	// ClusterAnnotation is the annotation on a VolumeSnapshot with the name of the RethinkDBCluster whose data volume
+	// claim was snapshotted. The names of the servers are stored in their data, so they are restored with the
+	// snapshots and are derived from the cluster name and the claim.
+	ClusterAnnotation = "rethinkdb.com/cluster"
+
+	// kind is the kind of the VolumeSnapshot resource.
+	kind = "VolumeSnapshot"
+)
+
+// GVK is the group, version and kind of the VolumeSnapshot resource.
+var GVK = schema.GroupVersionKind{Group: Group, Version: "v1alpha1", Kind: kind}
+
+// ClaimName returns the name of the PersistentVolumeClaim the given VolumeSnapshot was taken of.
+func ClaimName(snapshot *unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "name")
+	return name
+}
+
+// ClusterName returns the name of the RethinkDBCluster the given VolumeSnapshot was taken of.
+func ClusterName(snapshot *unstructured.Unstructured) string {
+	return snapshot.GetAnnotations()[ClusterAnnotation]
+}
+
+// CredentialsSecretName returns the name of the Secret with the admin password of the cluster for the snapshots
+// taken by the backup Job with the given name. The users of the cluster are stored in the data of the servers, so a
+// cluster provisioned from the snapshots uses the admin password the cluster had when the snapshots were taken.
+func CredentialsSecretName(jobName string) string {
+	return fmt.Sprintf("%s-admin", jobName)
+}
+
+// DataSource returns the data source of a PersistentVolumeClaim provisioned from the VolumeSnapshot with the given
+// name.
+func DataSource(name string) *corev1.TypedLocalObjectReference {
+	group := Group
+	return &corev1.TypedLocalObjectReference{
+		APIGroup: &group,
+		Kind:     kind,
+		Name:     name,
+	}
+}
+
+// Error returns the error reported by the given VolumeSnapshot, or an empty string if there is none.
+func Error(snapshot *unstructured.Unstructured) string {
+	message, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return message
+}
+
+// IsCreated returns true once the data of the given VolumeSnapshot has been cut, which may be long before the
+// snapshot is ready to use.
+func IsCreated(snapshot *unstructured.Unstructured) bool {
+	created, _, _ := unstructured.NestedString(snapshot.Object, "status", "creationTime")
+	return created != "" || IsReady(snapshot)
+}
+
+// IsReady returns true if the given VolumeSnapshot can be used to provision a PersistentVolumeClaim.
+func IsReady(snapshot *unstructured.Unstructured) bool {
+	ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	return ready
+}
+
+// New returns a VolumeSnapshot with the given name of the PersistentVolumeClaim with the given name of the given
+// RethinkDBCluster, taken with the given VolumeSnapshotClass, or the default class when empty.
+func New(name string, namespace string, claimName string, clusterName string, className string, labels map[string]string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"kind": "PersistentVolumeClaim",
+			"name": claimName,
+		},
+	}
+	if className != "" {
+		spec["snapshotClassName"] = className
+	}
+
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GVK)
+	snapshot.SetName(name)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetLabels(labels)
+	snapshot.SetAnnotations(map[string]string{ClusterAnnotation: clusterName})
+	snapshot.Object["spec"] = spec
+	return snapshot
+}
+
+// NewList returns an empty list of VolumeSnapshots to list the snapshots into.
+func NewList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GVK.GroupVersion().WithKind(kind + "List"))
+	return list
+}
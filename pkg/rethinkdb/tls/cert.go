@@ -0,0 +1,291 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tls issues and validates the certificates of a RethinkDB cluster. It is shared by the controllers that
+// manage the cluster and the client certificates, so both follow the same TLS policy.
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	tlsutil "github.com/operator-framework/operator-sdk/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// CACertKey is the key for tls CA certificates.
+	CACertKey = tlsutil.TLSCACertKey
+
+	// CertKey is the key for tls certificates.
+	CertKey = corev1.TLSCertKey
+)
+
+// EncodeCertificatePEM encodes the given certificate pem and returns bytes (base64).
+func EncodeCertificatePEM(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert.Raw,
+	})
+}
+
+// EncodePrivateKeyPEM encodes the given private key pem and returns bytes (base64).
+// RSA keys are encoded as PKCS#1, ECDSA keys as SEC 1 and any other key as PKCS#8.
+func EncodePrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(k),
+		}), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}), nil
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	}), nil
+}
+
+// ExtKeyUsageName returns the name of the given extended key usage.
+func ExtKeyUsageName(usage x509.ExtKeyUsage) string {
+	switch usage {
+	case x509.ExtKeyUsageClientAuth:
+		return "client auth"
+	case x509.ExtKeyUsageServerAuth:
+		return "server auth"
+	}
+	return fmt.Sprintf("%d", usage)
+}
+
+// HasExtKeyUsage returns true if the given certificate may be used for the given extended key usage.
+func HasExtKeyUsage(cert *x509.Certificate, usage x509.ExtKeyUsage) bool {
+	if len(cert.ExtKeyUsage) <= 0 {
+		return true
+	}
+	for _, u := range cert.ExtKeyUsage {
+		if u == usage || u == x509.ExtKeyUsageAny {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPrivateKey returns randomly generated private key with the given algorithm and size in bits.
+func NewPrivateKey(algorithm v1alpha1.RethinkDBKeyAlgorithm, size int) (crypto.Signer, error) {
+	switch algorithm {
+	case v1alpha1.KeyAlgorithmRSA:
+		if size != 2048 && size != 3072 && size != 4096 {
+			return nil, fmt.Errorf("unsupported RSA key size %d", size)
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	case v1alpha1.KeyAlgorithmECDSA:
+		curve, err := ecdsaCurve(size)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	}
+	return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+}
+
+// NewSelfSignedCACertificate returns a self-signed CA certificate based on given configuration and private key.
+// The certificate has the given lifetime and subject organization.
+func NewSelfSignedCACertificate(key crypto.Signer, duration time.Duration, organization []string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tmpl := x509.Certificate{
+		Subject: pkix.Name{
+			Organization: organization,
+		},
+		SerialNumber:          serial,
+		NotBefore:             now.UTC(),
+		NotAfter:              now.Add(duration).UTC(),
+		KeyUsage:              keyUsage(key) | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDERBytes, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certDERBytes)
+}
+
+// NewSignedCertificate signs a certificate using the given private key, CA and returns a signed certificate.
+// The certificate could be used for both client and server auth.
+// The certificate has the given lifetime.
+func NewSignedCertificate(cfg *tlsutil.CertConfig, dnsNames []string, duration time.Duration, key crypto.Signer, caCert *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	eku := []x509.ExtKeyUsage{}
+	switch cfg.CertType {
+	case tlsutil.ClientCert:
+		eku = append(eku, x509.ExtKeyUsageClientAuth)
+	case tlsutil.ServingCert:
+		eku = append(eku, x509.ExtKeyUsageServerAuth)
+	case tlsutil.ClientAndServingCert:
+		eku = append(eku, x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth)
+	}
+	certTmpl := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   cfg.CommonName,
+			Organization: cfg.Organization,
+		},
+		DNSNames:     dnsNames,
+		SerialNumber: serial,
+		NotBefore:    caCert.NotBefore,
+		NotAfter:     time.Now().Add(duration).UTC(),
+		KeyUsage:     keyUsage(key),
+		ExtKeyUsage:  eku,
+	}
+	certDERBytes, err := x509.CreateCertificate(rand.Reader, &certTmpl, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certDERBytes)
+}
+
+// ParsePEMEncodedCert parses a certificate from the given pemdata
+func ParsePEMEncodedCert(pemdata []byte) (*x509.Certificate, error) {
+	decoded, _ := pem.Decode(pemdata)
+	if decoded == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	return x509.ParseCertificate(decoded.Bytes)
+}
+
+// ParsePEMEncodedPrivateKey parses a PKCS#1 RSA, SEC 1 EC or PKCS#8 private key from given pemdata
+func ParsePEMEncodedPrivateKey(pemdata []byte) (crypto.Signer, error) {
+	decoded, _ := pem.Decode(pemdata)
+	if decoded == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	switch decoded.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(decoded.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(decoded.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(decoded.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
+
+// ValidateCASecret returns an error if the given Secret does not hold a valid CA certificate and matching private key.
+func ValidateCASecret(secret *corev1.Secret) error {
+	cert, err := ValidateKeyPair(secret)
+	if err != nil {
+		return err
+	}
+
+	if !cert.IsCA {
+		return errors.New("certificate is not a CA")
+	}
+	return nil
+}
+
+// ValidateCertificateSecret returns an error if the given Secret does not hold a valid certificate and matching
+// private key, signed by the given CA certificate.
+func ValidateCertificateSecret(secret *corev1.Secret, caCert *x509.Certificate) error {
+	cert, err := ValidateKeyPair(secret)
+	if err != nil {
+		return err
+	}
+
+	if err = cert.CheckSignatureFrom(caCert); err != nil {
+		return fmt.Errorf("certificate is not signed by the cluster CA: %v", err)
+	}
+	return nil
+}
+
+// ValidateKeyPair parses the certificate and private key in the given TLS Secret and verifies they belong together.
+// The parsed certificate is returned upon success.
+func ValidateKeyPair(secret *corev1.Secret) (*x509.Certificate, error) {
+	if _, err := ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey]); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", corev1.TLSCertKey, err)
+	}
+
+	pair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", corev1.TLSPrivateKeyKey, err)
+	}
+	return x509.ParseCertificate(pair.Certificate[0])
+}
+
+// ecdsaCurve returns the elliptic curve for ECDSA keys with the given size in bits.
+func ecdsaCurve(size int) (elliptic.Curve, error) {
+	switch size {
+	case 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	}
+	return nil, fmt.Errorf("unsupported ECDSA key size %d", size)
+}
+
+// keyUsage returns the key usage for a certificate with the given private key.
+// Only RSA keys are used for key encipherment.
+func keyUsage(key crypto.Signer) x509.KeyUsage {
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		return x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature
+	}
+	return x509.KeyUsageDigitalSignature
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tls
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	tlsutil "github.com/operator-framework/operator-sdk/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClientCertificateDuration returns the given lifetime of a client certificate for the given RethinkDBCluster, or the
+// lifetime of the cluster certificates when not set.
+func ClientCertificateDuration(cr *v1alpha1.RethinkDBCluster, duration *metav1.Duration) time.Duration {
+	if duration != nil && duration.Duration > 0 {
+		return duration.Duration
+	}
+	return CertificateDuration(cr)
+}
+
+// ClientCertificateRenewalTime returns the time the given client certificate with the given lifetime is renewed. The
+// given renewal window defaults to the renewal window of the certificates for the given RethinkDBCluster.
+func ClientCertificateRenewalTime(cr *v1alpha1.RethinkDBCluster, cert *x509.Certificate, duration time.Duration, before *metav1.Duration) time.Time {
+	window := RenewBefore(cr)
+	if before != nil && before.Duration > 0 {
+		window = before.Duration
+	}
+	return cert.NotAfter.Add(-RenewalWindow(window, duration))
+}
+
+// ClientCertSecretName returns the name of the Secret with the client certificate for the given RethinkDBClientCert.
+func ClientCertSecretName(cc *v1alpha1.RethinkDBClientCert) string {
+	if cc.Spec.SecretName != "" {
+		return cc.Spec.SecretName
+	}
+	return cc.Name
+}
+
+// IssueClientCertificate issues a client certificate with the given common name and lifetime from the CA in the
+// given Secret, using the key policy of the given RethinkDBCluster. The PEM encoded certificate and private key are
+// returned upon success.
+func IssueClientCertificate(cr *v1alpha1.RethinkDBCluster, caSecret *corev1.Secret, commonName string, duration time.Duration) ([]byte, []byte, error) {
+	caCert, err := ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caKey, err := ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := NewPrivateKey(KeyAlgorithm(cr), KeySize(cr))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := &tlsutil.CertConfig{
+		CertName:     commonName,
+		CertType:     tlsutil.ClientCert,
+		CommonName:   commonName,
+		Organization: CertificateOrganization(cr),
+	}
+
+	cert, err := NewSignedCertificate(cfg, nil, duration, key, caCert, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := EncodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return EncodeCertificatePEM(cert), keyPEM, nil
+}
+
+// ValidateClientCertificate verifies the client certificate in the given TLS Secret is signed by the CA in the given
+// CA Secret, has the given common name and matches the key policy of the given RethinkDBCluster. The parsed
+// certificate is returned upon success.
+func ValidateClientCertificate(cr *v1alpha1.RethinkDBCluster, caSecret *corev1.Secret, secret *corev1.Secret, commonName string) (*x509.Certificate, error) {
+	caCert, err := ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ValidateCertificateSecret(secret, caCert); err != nil {
+		return nil, err
+	}
+
+	cert, err := ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+
+	if cert.Subject.CommonName != commonName {
+		return nil, fmt.Errorf("common name %q does not match %q", cert.Subject.CommonName, commonName)
+	}
+	if !HasExtKeyUsage(cert, x509.ExtKeyUsageClientAuth) {
+		return nil, fmt.Errorf("extended key usage does not include %s", ExtKeyUsageName(x509.ExtKeyUsageClientAuth))
+	}
+	if !KeyMatchesPolicy(cr, cert) {
+		return nil, errors.New("key does not match the key policy of the cluster")
+	}
+	return cert, nil
+}
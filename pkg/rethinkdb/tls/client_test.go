@@ -0,0 +1,145 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tls
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTestCASecret returns a CA Secret with a new self-signed CA certificate.
+func newTestCASecret(t *testing.T) *corev1.Secret {
+	key, err := NewPrivateKey(v1alpha1.KeyAlgorithmECDSA, ecdsaKeySize)
+	if err != nil {
+		t.Fatalf("unable to create CA key: %v", err)
+	}
+
+	cert, err := NewSelfSignedCACertificate(key, duration365d, []string{"test"})
+	if err != nil {
+		t.Fatalf("unable to create CA certificate: %v", err)
+	}
+
+	keyPEM, err := EncodePrivateKeyPEM(key)
+	if err != nil {
+		t.Fatalf("unable to encode CA key: %v", err)
+	}
+
+	return &corev1.Secret{Data: map[string][]byte{
+		corev1.TLSCertKey:       EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}}
+}
+
+// newTestCluster returns a RethinkDBCluster with ECDSA keys and the given certificate lifetime and renewal window.
+func newTestCluster(duration time.Duration, renewBefore time.Duration) *v1alpha1.RethinkDBCluster {
+	return &v1alpha1.RethinkDBCluster{
+		Spec: v1alpha1.RethinkDBClusterSpec{
+			TLS: &v1alpha1.RethinkDBTLSPolicy{
+				Duration:     &metav1.Duration{Duration: duration},
+				KeyAlgorithm: v1alpha1.KeyAlgorithmECDSA,
+				KeySize:      ecdsaKeySize,
+				RenewBefore:  &metav1.Duration{Duration: renewBefore},
+			},
+		},
+	}
+}
+
+func TestClientCertificateDuration(t *testing.T) {
+	cr := newTestCluster(48*time.Hour, 12*time.Hour)
+
+	tests := []struct {
+		name     string
+		cr       *v1alpha1.RethinkDBCluster
+		duration *metav1.Duration
+		want     time.Duration
+	}{
+		{"default", &v1alpha1.RethinkDBCluster{}, nil, defaultCertificateDuration},
+		{"cluster", cr, nil, 48 * time.Hour},
+		{"zero", cr, &metav1.Duration{}, 48 * time.Hour},
+		{"override", cr, &metav1.Duration{Duration: 24 * time.Hour}, 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := ClientCertificateDuration(tt.cr, tt.duration); got != tt.want {
+			t.Errorf("%s: got duration %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClientCertificateRenewalTime(t *testing.T) {
+	cr := newTestCluster(48*time.Hour, 12*time.Hour)
+	notAfter := time.Date(2019, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		before   *metav1.Duration
+		want     time.Duration
+	}{
+		{"cluster", 48 * time.Hour, nil, 12 * time.Hour},
+		{"override", 48 * time.Hour, &metav1.Duration{Duration: 6 * time.Hour}, 6 * time.Hour},
+		{"longer than lifetime", 9 * time.Hour, nil, 3 * time.Hour},
+		{"override longer than lifetime", 48 * time.Hour, &metav1.Duration{Duration: 96 * time.Hour}, 16 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		got := ClientCertificateRenewalTime(cr, &x509.Certificate{NotAfter: notAfter}, tt.duration, tt.before)
+		if want := notAfter.Add(-tt.want); !got.Equal(want) {
+			t.Errorf("%s: got renewal time %s, want %s", tt.name, got, want)
+		}
+	}
+}
+
+func TestValidateClientCertificate(t *testing.T) {
+	cr := newTestCluster(48*time.Hour, 12*time.Hour)
+	caSecret := newTestCASecret(t)
+
+	certPEM, keyPEM, err := IssueClientCertificate(cr, caSecret, "orders", 48*time.Hour)
+	if err != nil {
+		t.Fatalf("unable to issue client certificate: %v", err)
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}}
+
+	cert, err := ValidateClientCertificate(cr, caSecret, secret, "orders")
+	if err != nil {
+		t.Fatalf("expected the issued certificate to be valid: %v", err)
+	}
+	if lifetime := cert.NotAfter.Sub(cert.NotBefore); lifetime < 48*time.Hour {
+		t.Errorf("got certificate lifetime %s, want at least %s", lifetime, 48*time.Hour)
+	}
+
+	// The certificate must be re-issued when the common name changes or the cluster CA is rotated
+	if _, err = ValidateClientCertificate(cr, caSecret, secret, "billing"); err == nil {
+		t.Error("expected an error for a mismatched common name")
+	}
+	if _, err = ValidateClientCertificate(cr, newTestCASecret(t), secret, "orders"); err == nil {
+		t.Error("expected an error for a certificate signed by another CA")
+	}
+
+	rsa := newTestCluster(48*time.Hour, 12*time.Hour)
+	rsa.Spec.TLS.KeyAlgorithm = v1alpha1.KeyAlgorithmRSA
+	rsa.Spec.TLS.KeySize = rsaKeySize
+	if _, err = ValidateClientCertificate(rsa, caSecret, secret, "orders"); err == nil {
+		t.Error("expected an error for a key that does not match the key policy")
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 The rethinkdb-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/jmckind/rethinkdb-operator/pkg/apis/rethinkdb/v1alpha1"
+)
+
+const (
+	rsaKeySize   = 2048
+	ecdsaKeySize = 256
+	duration365d = time.Hour * 24 * 365
+
+	// defaultCertificateDuration is the lifetime of the issued certificates, unless set in the spec.
+	defaultCertificateDuration = duration365d
+
+	// defaultRenewBefore is how long before expiry certificates are renewed, unless set in the spec.
+	defaultRenewBefore = time.Hour * 24 * 30
+
+	// CAKey is the name suffix of the CA Secret and the ConfigMap with the trusted CA certificates.
+	CAKey = "ca"
+
+	// ClientKey is the name suffix of the Secret with the client certificate of the operator.
+	ClientKey = "client"
+
+	// ClusterKey is the name suffix of the Secret with the certificate for the cluster port.
+	ClusterKey = "cluster"
+
+	// DriverKey is the name suffix of the Secret with the certificate for the driver port.
+	DriverKey = "driver"
+
+	// HTTPKey is the name suffix of the Secret with the certificate for the web-admin port.
+	HTTPKey = "http"
+)
+
+// CAConfigMapName returns the name of the ConfigMap with the bundle of trusted CA certificates for the given
+// RethinkDBCluster.
+func CAConfigMapName(cr *v1alpha1.RethinkDBCluster) string {
+	return fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, CAKey)
+}
+
+// CASecretName returns the name of the Secret holding the CA that signs the certificates for the given
+// RethinkDBCluster. This is either an existing Secret from the spec or the Secret with the generated CA.
+func CASecretName(cr *v1alpha1.RethinkDBCluster) string {
+	if HasExternalCA(cr) {
+		return cr.Spec.TLS.CASecret
+	}
+	return fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, CAKey)
+}
+
+// CertificateDuration returns the lifetime of the certificates issued for the given RethinkDBCluster.
+func CertificateDuration(cr *v1alpha1.RethinkDBCluster) time.Duration {
+	if cr.Spec.TLS != nil && cr.Spec.TLS.Duration != nil && cr.Spec.TLS.Duration.Duration > 0 {
+		return cr.Spec.TLS.Duration.Duration
+	}
+	return defaultCertificateDuration
+}
+
+// CertificateOrganization returns the subject organization of the certificates issued for the given RethinkDBCluster.
+func CertificateOrganization(cr *v1alpha1.RethinkDBCluster) []string {
+	if cr.Spec.TLS != nil && len(cr.Spec.TLS.Organization) > 0 {
+		return cr.Spec.TLS.Organization
+	}
+	return []string{cr.ObjectMeta.Namespace}
+}
+
+// Enabled returns true if the port with the given suffix serves TLS for the given RethinkDBCluster.
+// The setting for an individual port takes precedence over the setting for all ports.
+func Enabled(cr *v1alpha1.RethinkDBCluster, suffix string) bool {
+	if cr.Spec.TLS == nil {
+		return true
+	}
+
+	if ports := cr.Spec.TLS.Ports; ports != nil {
+		var enabled *bool
+		switch suffix {
+		case ClusterKey:
+			enabled = ports.Cluster
+		case DriverKey:
+			enabled = ports.Driver
+		case HTTPKey:
+			enabled = ports.HTTP
+		}
+		if enabled != nil {
+			return *enabled
+		}
+	}
+
+	if cr.Spec.TLS.Enabled != nil {
+		return *cr.Spec.TLS.Enabled
+	}
+	return true
+}
+
+// HasExternalCA returns true if an existing CA Secret is used for the given RethinkDBCluster.
+func HasExternalCA(cr *v1alpha1.RethinkDBCluster) bool {
+	return cr.Spec.TLS != nil && cr.Spec.TLS.CASecret != ""
+}
+
+// HasExternalCertificates returns true if existing TLS Secrets are used for the given RethinkDBCluster, instead of
+// certificates issued by the operator.
+func HasExternalCertificates(cr *v1alpha1.RethinkDBCluster) bool {
+	return cr.Spec.TLS != nil && cr.Spec.TLS.Secrets != nil
+}
+
+// IssuesCertificates returns true if the operator issues the TLS certificates for the given RethinkDBCluster,
+// rather than using existing certificates or certificates issued by cert-manager. Only then the operator holds the
+// CA that signs the certificates, so it is able to issue client certificates.
+func IssuesCertificates(cr *v1alpha1.RethinkDBCluster) bool {
+	return !HasExternalCertificates(cr) && !UsesCertManager(cr)
+}
+
+// KeyAlgorithm returns the algorithm of the private keys generated for the given RethinkDBCluster.
+func KeyAlgorithm(cr *v1alpha1.RethinkDBCluster) v1alpha1.RethinkDBKeyAlgorithm {
+	if cr.Spec.TLS != nil && cr.Spec.TLS.KeyAlgorithm != "" {
+		return cr.Spec.TLS.KeyAlgorithm
+	}
+	return v1alpha1.KeyAlgorithmRSA
+}
+
+// KeyMatchesPolicy returns true if the public key of the given certificate has the key algorithm and size from the
+// TLS policy of the given RethinkDBCluster.
+func KeyMatchesPolicy(cr *v1alpha1.RethinkDBCluster, cert *x509.Certificate) bool {
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return KeyAlgorithm(cr) == v1alpha1.KeyAlgorithmRSA && pub.N.BitLen() == KeySize(cr)
+	case *ecdsa.PublicKey:
+		return KeyAlgorithm(cr) == v1alpha1.KeyAlgorithmECDSA && pub.Curve.Params().BitSize == KeySize(cr)
+	}
+	return false
+}
+
+// KeySize returns the size in bits of the private keys generated for the given RethinkDBCluster.
+func KeySize(cr *v1alpha1.RethinkDBCluster) int {
+	if cr.Spec.TLS != nil && cr.Spec.TLS.KeySize > 0 {
+		return int(cr.Spec.TLS.KeySize)
+	}
+	if KeyAlgorithm(cr) == v1alpha1.KeyAlgorithmECDSA {
+		return ecdsaKeySize
+	}
+	return rsaKeySize
+}
+
+// RenewBefore returns how long before expiry the certificates for the given RethinkDBCluster are renewed.
+func RenewBefore(cr *v1alpha1.RethinkDBCluster) time.Duration {
+	if cr.Spec.TLS != nil && cr.Spec.TLS.RenewBefore != nil && cr.Spec.TLS.RenewBefore.Duration > 0 {
+		return cr.Spec.TLS.RenewBefore.Duration
+	}
+	return defaultRenewBefore
+}
+
+// RenewalWindow returns how long before expiry a certificate with the given lifetime is renewed. The window is
+// limited to a third of the lifetime, so that a renewed certificate is not due for renewal again right away.
+func RenewalWindow(renewBefore time.Duration, lifetime time.Duration) time.Duration {
+	if renewBefore >= lifetime {
+		return lifetime / 3
+	}
+	return renewBefore
+}
+
+// SecretName returns the name of the TLS Secret with the given suffix for the given RethinkDBCluster.
+// Existing Secrets from the spec take precedence over the Secrets issued by the operator.
+func SecretName(cr *v1alpha1.RethinkDBCluster, suffix string) string {
+	if HasExternalCertificates(cr) {
+		secrets := cr.Spec.TLS.Secrets
+		switch suffix {
+		case ClusterKey:
+			return secrets.Cluster
+		case DriverKey:
+			return secrets.Driver
+		case HTTPKey:
+			return secrets.HTTP
+		case ClientKey:
+			return secrets.Client
+		}
+	}
+	return fmt.Sprintf("%s-%s", cr.ObjectMeta.Name, suffix)
+}
+
+// UsesCertManager returns true if cert-manager issues the TLS certificates for the given RethinkDBCluster.
+func UsesCertManager(cr *v1alpha1.RethinkDBCluster) bool {
+	return cr.Spec.TLS != nil && cr.Spec.TLS.CertManager != nil && !HasExternalCertificates(cr)
+}